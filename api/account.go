@@ -14,6 +14,8 @@ import (
 type createAccountRequest struct {
 	// Owner    string `json:"owner" binding:"required"`
 	Currency string `json:"currency" binding:"required,currency"`
+	Type     string `json:"type" binding:"omitempty,oneof=checking savings"`
+	Nickname string `json:"nickname" binding:"max=50"`
 }
 
 func (s *Server) createAccount(ctx *gin.Context) {
@@ -25,9 +27,15 @@ func (s *Server) createAccount(ctx *gin.Context) {
 
 	// get owner
 	payload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	accountType := req.Type
+	if accountType == "" {
+		accountType = db.AccountTypeChecking
+	}
 	acc, err := s.store.CreateAccount(ctx, db.CreateAccountParams{
 		Owner:    payload.Username,
 		Currency: req.Currency,
+		Type:     accountType,
+		Nickname: req.Nickname,
 	})
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
@@ -67,7 +75,7 @@ func (s *Server) getAccount(ctx *gin.Context) {
 
 	// get owner
 	payload := ctx.MustGet(authPayloadKey).(*token.Payload)
-	if acc.Owner != payload.Username {
+	if acc.Owner.String != payload.Username {
 		err := fmt.Errorf("account does not belong to authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
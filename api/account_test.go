@@ -34,7 +34,7 @@ func TestGetAccountApi(t *testing.T) {
 			accountID: account.ID,
 			name:      "when valid account should get account - OK",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), account.ID).Times(1).Return(account, nil)
@@ -48,7 +48,7 @@ func TestGetAccountApi(t *testing.T) {
 			accountID: account.ID,
 			name:      "Unauthorized user",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), account.ID).Times(1).Return(account, nil)
@@ -73,7 +73,7 @@ func TestGetAccountApi(t *testing.T) {
 			accountID: account.ID,
 			name:      "when no account found should return - 404",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), account.ID).Times(1).Return(db.Account{}, sql.ErrNoRows)
@@ -86,7 +86,7 @@ func TestGetAccountApi(t *testing.T) {
 			accountID: 0,
 			name:      "when invalid account request should return - 400",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0).Return(db.Account{}, nil)
@@ -99,7 +99,7 @@ func TestGetAccountApi(t *testing.T) {
 			accountID: account.ID,
 			name:      "when store error should return - 500",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), account.ID).Times(1).Return(db.Account{}, sql.ErrConnDone)
@@ -153,17 +153,18 @@ func TestCreateAccountApi(t *testing.T) {
 		{
 			accountID: account.ID,
 			body: gin.H{
-				"owner":    account.Owner,
+				"owner":    account.Owner.String,
 				"currency": account.Currency,
 			},
 			name: "when valid request should create account - OK",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().CreateAccount(gomock.Any(), db.CreateAccountParams{
 					Owner:    user.Username,
 					Currency: account.Currency,
+					Type:     db.AccountTypeChecking,
 				}).Times(1).Return(account, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -174,12 +175,12 @@ func TestCreateAccountApi(t *testing.T) {
 		{
 			accountID: account.ID,
 			body: gin.H{
-				"owner":    account.Owner,
+				"owner":    account.Owner.String,
 				"currency": "XXX",
 			},
 			name: "when ivalid request should return - 400",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0).Return(db.Account{}, nil)
@@ -196,12 +197,13 @@ func TestCreateAccountApi(t *testing.T) {
 			},
 			name: "when error in store should return - 500",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().CreateAccount(gomock.Any(), db.CreateAccountParams{
 					Owner:    user.Username,
 					Currency: account.Currency,
+					Type:     db.AccountTypeChecking,
 				}).Times(1).Return(db.Account{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -268,7 +270,7 @@ func TestListAccountApi(t *testing.T) {
 			},
 			name: "when valid request should list account - OK",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().ListAccounts(gomock.Any(), db.ListAccountsParams{
@@ -291,7 +293,7 @@ func TestListAccountApi(t *testing.T) {
 			},
 			name: "when invalid list request should return - 400",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0).Return(accounts, nil)
@@ -307,7 +309,7 @@ func TestListAccountApi(t *testing.T) {
 			},
 			name: "when eror in store should return - 500",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.RoleCustomer, time.Minute)
 			},
 			setStubs: func(store *db_mock.MockStore) {
 				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{}, sql.ErrConnDone)
@@ -350,9 +352,11 @@ func TestListAccountApi(t *testing.T) {
 func randomAccount(owner string) db.Account {
 	return db.Account{
 		ID:       util.RandomInt(1, 1000),
-		Owner:    owner,
+		Owner:    sql.NullString{String: owner, Valid: true},
 		Balance:  util.RandonMoney(),
 		Currency: util.RandonCurrency(),
+		Status:   db.AccountStatusActive,
+		Type:     db.AccountTypeChecking,
 	}
 }
 
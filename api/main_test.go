@@ -6,6 +6,8 @@ import (
 	"time"
 
 	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fx"
+	"github.com/dibrito/simple-bank/token"
 	"github.com/dibrito/simple-bank/util"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -16,7 +18,10 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 		TokenSymmetricKey: util.RandomString(32),
 		AccessDuration:    time.Minute,
 	}
-	server, err := NewServer(config, store)
+	server, err := NewServer(config, store, token.NewMemoryRevoker(time.Hour))
+	require.NoError(t, err)
+
+	server.rates, err = fx.NewStaticProvider(map[string]string{"EUR/USD": "1.08"})
 	require.NoError(t, err)
 	return server
 }
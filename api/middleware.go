@@ -16,7 +16,7 @@ const (
 	authPayloadKey          = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Maker, revoker token.Revoker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// extract header
 		header := ctx.GetHeader(authHeaderKey)
@@ -48,6 +48,12 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
+		err = revoker.CheckToken(ctx, payload)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+
 		ctx.Set(authPayloadKey, payload)
 		ctx.Next()
 	}
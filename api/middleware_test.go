@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -8,13 +9,14 @@ import (
 	"time"
 
 	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 )
 
-func addAuthorization(t *testing.T, request *http.Request, tokenMaker token.Maker, authorizationType, username string, duration time.Duration) {
+func addAuthorization(t *testing.T, request *http.Request, tokenMaker token.Maker, authorizationType, username, role string, duration time.Duration) {
 	// create token
-	token, payload, err := tokenMaker.CreateToken(username, duration)
+	token, payload, err := tokenMaker.CreateToken(username, role, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
@@ -31,7 +33,7 @@ func TestAuthMiddleware(t *testing.T) {
 		{
 			name: "when valid request should return StatusOK",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", util.RoleCustomer, time.Minute)
 			},
 			checkResponse: func(t *testing.T, response *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, response.Code)
@@ -48,7 +50,7 @@ func TestAuthMiddleware(t *testing.T) {
 		{
 			name: "when invalid authorization header format should return StatusUnauthorized",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, "unsupported", "user", time.Minute)
+				addAuthorization(t, request, tokenMaker, "unsupported", "user", util.RoleCustomer, time.Minute)
 			},
 			checkResponse: func(t *testing.T, response *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, response.Code)
@@ -58,7 +60,7 @@ func TestAuthMiddleware(t *testing.T) {
 			name: "when invalid authorization header format should return StatusUnauthorized",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				// empty auth type will break len of fields
-				addAuthorization(t, request, tokenMaker, "", "user", time.Minute)
+				addAuthorization(t, request, tokenMaker, "", "user", util.RoleCustomer, time.Minute)
 			},
 			checkResponse: func(t *testing.T, response *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, response.Code)
@@ -67,7 +69,7 @@ func TestAuthMiddleware(t *testing.T) {
 		{
 			name: "when expired token should return StatusUnauthorized",
 			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", -time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", util.RoleCustomer, -time.Minute)
 			},
 			checkResponse: func(t *testing.T, response *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, response.Code)
@@ -79,7 +81,7 @@ func TestAuthMiddleware(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			server := newTestServer(t, nil)
 			// handler for auth
-			server.router.GET("/auth", authMiddleware(server.tokenMaker), func(ctx *gin.Context) {
+			server.router.GET("/auth", authMiddleware(server.tokenMaker, server.revoker), func(ctx *gin.Context) {
 				ctx.JSON(http.StatusOK, gin.H{})
 			})
 
@@ -96,3 +98,52 @@ func TestAuthMiddleware(t *testing.T) {
 
 	}
 }
+
+func TestAuthMiddlewareRevokedToken(t *testing.T) {
+	tcs := []struct {
+		name   string
+		revoke func(t *testing.T, revoker token.Revoker, payload *token.Payload)
+		status int
+	}{
+		{
+			name:   "when token is not revoked should return StatusOK",
+			revoke: func(t *testing.T, revoker token.Revoker, payload *token.Payload) {},
+			status: http.StatusOK,
+		},
+		{
+			name: "when token is revoked should return StatusUnauthorized",
+			revoke: func(t *testing.T, revoker token.Revoker, payload *token.Payload) {
+				require.NoError(t, revoker.RevokeToken(context.Background(), payload))
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "when token was issued before the user watermark should return StatusUnauthorized",
+			revoke: func(t *testing.T, revoker token.Revoker, payload *token.Payload) {
+				require.NoError(t, revoker.RevokeUserTokens(context.Background(), payload.Username, time.Now()))
+			},
+			status: http.StatusUnauthorized,
+		},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, nil)
+			server.router.GET("/auth", authMiddleware(server.tokenMaker, server.revoker), func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			})
+
+			accessToken, payload, err := server.tokenMaker.CreateToken("user", util.RoleCustomer, time.Minute)
+			require.NoError(t, err)
+			tc.revoke(t, server.revoker, payload)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/auth", nil)
+			require.NoError(t, err)
+			request.Header.Set(authHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, accessToken))
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.status, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/gin-gonic/gin"
+)
+
+// permissions lists the roles allowed on each route behind authMiddleware,
+// by method and path pattern. A route missing from it is denied to everyone,
+// so a new route is unreachable until it is listed.
+var permissions = map[string][]string{
+	"POST /accounts":    {util.RoleCustomer},
+	"GET /accounts/:id": {util.RoleCustomer},
+	"GET /accounts/":    {util.RoleCustomer},
+	"POST /transfers":   {util.RoleCustomer},
+}
+
+// permissionMiddleware rejects the requests whose role may not use the route.
+// It runs after authMiddleware.
+func permissionMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := ctx.MustGet(authPayloadKey).(*token.Payload)
+		route := fmt.Sprintf("%s %s", ctx.Request.Method, ctx.FullPath())
+
+		for _, role := range permissions[route] {
+			if role == payload.Role {
+				ctx.Next()
+				return
+			}
+		}
+
+		err := fmt.Errorf("role %q is not allowed to %s", payload.Role, route)
+		ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dibrito/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionMiddleware(t *testing.T) {
+	tcs := []struct {
+		name   string
+		path   string
+		role   string
+		status int
+	}{
+		{
+			name:   "when role is allowed should return StatusOK",
+			path:   "/accounts",
+			role:   util.RoleCustomer,
+			status: http.StatusOK,
+		},
+		{
+			name:   "when role is not allowed should return StatusForbidden",
+			path:   "/accounts",
+			role:   util.RoleAuditor,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "when route is not listed should return StatusForbidden",
+			path:   "/unlisted",
+			role:   util.RoleAdmin,
+			status: http.StatusForbidden,
+		},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, nil)
+			router := gin.New()
+			router.POST(tc.path, authMiddleware(server.tokenMaker, server.revoker), permissionMiddleware(), func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, tc.path, nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "user", tc.role, time.Minute)
+
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.status, recorder.Code)
+		})
+	}
+}
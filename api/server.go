@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fx"
 	"github.com/dibrito/simple-bank/token"
 	"github.com/dibrito/simple-bank/util"
 	"github.com/gin-gonic/gin"
@@ -15,25 +16,35 @@ import (
 type Server struct {
 	store      db.Store
 	tokenMaker token.Maker
+	revoker    token.Revoker
 	router     *gin.Engine
 	config     util.Config
+	rates      fx.RateProvider
 }
 
 // NewServer creates a new HTTP server and setup routing.
-func NewServer(config util.Config, store db.Store) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+// Tokens revoked with revoker are rejected.
+func NewServer(config util.Config, store db.Store, revoker token.Revoker) (*Server, error) {
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("can not create token maker: %v", err)
 	}
+	rates, err := fx.NewProvider(config.FXRatesFile)
+	if err != nil {
+		return nil, fmt.Errorf("can not create rate provider: %v", err)
+	}
 	server := &Server{
 		store:      store,
 		config:     config,
 		tokenMaker: tokenMaker,
+		revoker:    revoker,
+		rates:      rates,
 	}
 
 	// bind custom validators
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("tag", validTag)
 	}
 
 	server.setupRouter()
@@ -47,7 +58,7 @@ func (s *Server) setupRouter() {
 
 	router.POST("/tokens/renew_access", s.renewAccessToken)
 
-	authRoutes := router.Group("/").Use(authMiddleware(s.tokenMaker))
+	authRoutes := router.Group("/").Use(authMiddleware(s.tokenMaker, s.revoker), permissionMiddleware())
 
 	// note POST receives multipe funcs and and last is the handler
 	// others are middlewares
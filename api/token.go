@@ -2,11 +2,14 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	db "github.com/dibrito/simple-bank/db/sqlc"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type renewAccessTokenRequest struct {
@@ -15,8 +18,11 @@ type renewAccessTokenRequest struct {
 }
 
 type renewAccessTokenResponse struct {
-	AccessToken         string    `json:"access_token"`
-	AccessTokenExpireAt time.Time `json:"access_token_expire_at"`
+	AccessToken          string    `json:"access_token"`
+	AccessTokenExpireAt  time.Time `json:"access_token_expire_at"`
+	RefreshToken         string    `json:"refresh_token"`
+	RefreshTokenExpireAt time.Time `json:"refresh_token_expire_at"`
+	SessionID            uuid.UUID `json:"session_id"`
 }
 
 func (s *Server) renewAccessToken(ctx *gin.Context) {
@@ -31,6 +37,11 @@ func (s *Server) renewAccessToken(ctx *gin.Context) {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
+	err = s.revoker.CheckToken(ctx, refreshTokenPayload)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
 
 	// retrieve session
 
@@ -64,18 +75,59 @@ func (s *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
+	// the role is read again, a change takes effect on the next renewal
+	user, err := s.store.GetUser(ctx, session.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	// create new token
 	accessToken, accessTokenPayload, err := s.tokenMaker.CreateToken(
-		refreshTokenPayload.Username,
+		user.Username,
+		user.Role,
 		s.config.AccessDuration)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
+	// the refresh token is single use, a new one continues the session
+	refreshToken, newRefreshTokenPayload, err := s.tokenMaker.CreateToken(
+		user.Username,
+		user.Role,
+		s.config.RefreshDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	next, err := s.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		Session: session,
+		NewSession: db.CreateSessionParams{
+			ID:           newRefreshTokenPayload.ID,
+			Username:     session.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    ctx.Request.UserAgent(),
+			ClientIp:     ctx.ClientIP(),
+			ExpiresAt:    newRefreshTokenPayload.ExpireAt,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	resp := renewAccessTokenResponse{
-		AccessToken:         accessToken,
-		AccessTokenExpireAt: accessTokenPayload.ExpireAt,
+		AccessToken:          accessToken,
+		AccessTokenExpireAt:  accessTokenPayload.ExpireAt,
+		RefreshToken:         refreshToken,
+		RefreshTokenExpireAt: newRefreshTokenPayload.ExpireAt,
+		SessionID:            next.ID,
 	}
 
 	ctx.JSON(http.StatusOK, resp)
@@ -2,19 +2,33 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/dibrito/simple-bank/currency"
 	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fee"
+	"github.com/dibrito/simple-bank/fx"
 	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/val"
 	"github.com/gin-gonic/gin"
 )
 
+// idempotencyKeyHeader lets clients retry a transfer without moving money twice.
+const idempotencyKeyHeader = "Idempotency-Key"
+
 type createTransferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	// the recipient is given by exactly one of ToAccountID, ToUsername or ToEmail
+	ToAccountID       int64    `json:"to_account_id" binding:"omitempty,min=1"`
+	ToUsername        string   `json:"to_username"`
+	ToEmail           string   `json:"to_email" binding:"omitempty,email"`
+	Amount            int64    `json:"amount" binding:"required,gt=0"`
+	Currency          string   `json:"currency" binding:"required,currency"`
+	Description       string   `json:"description" binding:"max=255"`
+	ExternalReference string   `json:"external_reference" binding:"max=100"`
+	Tags              []string `json:"tags" binding:"max=10,unique,dive,tag"`
 }
 
 func (s *Server) createTransfer(ctx *gin.Context) {
@@ -23,30 +37,103 @@ func (s *Server) createTransfer(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	if countSet(req.ToAccountID != 0, req.ToUsername != "", req.ToEmail != "") != 1 {
+		err := fmt.Errorf("exactly one of to_account_id, to_username or to_email must be set")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
 	fromAccount, valid := s.validAccount(ctx, req.FromAccountID, req.Currency)
 	if !valid {
 		return
 	}
 	payload := ctx.MustGet(authPayloadKey).(*token.Payload)
-	if fromAccount.Owner != payload.Username {
+	if fromAccount.Owner.String != payload.Username {
 		err := fmt.Errorf("from account does not belong to authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
-	_, valid = s.validAccount(ctx, req.ToAccountID, req.Currency)
+	// the destination may hold another currency, the amount is converted
+	var toAccount db.Account
+	if req.ToAccountID != 0 {
+		toAccount, valid = s.existingAccount(ctx, req.ToAccountID)
+	} else {
+		toAccount, valid = s.resolvePayee(ctx, req.ToUsername, req.ToEmail, req.Currency)
+	}
 	if !valid {
 		return
 	}
 
+	rate, err := s.rates.Rate(ctx, fromAccount.Currency, toAccount.Currency)
+	if err != nil {
+		if errors.Is(err, fx.ErrRateNotFound) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	fromCurrency, _ := currency.Get(fromAccount.Currency)
+	toCurrency, ok := currency.Get(toAccount.Currency)
+	if !ok {
+		err := fmt.Errorf("unknown currency %s", toAccount.Currency)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	toAmount := fx.ConvertMinor(req.Amount, rate, fromCurrency.MinorUnit, toCurrency.MinorUnit)
+	if toAmount <= 0 {
+		err := fmt.Errorf("amount is too small to convert to %s", toAccount.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transferFee, err := fee.ForTransfer(ctx, s.store, fromAccount.Currency, toAccount.Currency, req.Amount)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	idempotencyKey := ctx.GetHeader(idempotencyKeyHeader)
+	if err := val.ValidateIdempotencyKey(idempotencyKey); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("%s %w", idempotencyKeyHeader, err)))
+		return
+	}
+
 	transfer, err := s.store.TransferTx(ctx, db.TransferTxParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+		FromAccountID:     req.FromAccountID,
+		ToAccountID:       toAccount.ID,
+		Amount:            req.Amount,
+		Currency:          req.Currency,
+		ToAmount:          toAmount,
+		ExchangeRate:      fx.FormatRate(rate),
+		Fee:               transferFee,
+		Username:          payload.Username,
+		IdempotencyKey:    idempotencyKey,
+		Description:       req.Description,
+		ExternalReference: req.ExternalReference,
+		Tags:              req.Tags,
 	})
 
 	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) ||
+			errors.Is(err, db.ErrAccountFrozen) ||
+			errors.Is(err, db.ErrAccountClosed) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrSingleTransferLimit) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrDailyTransferLimit) {
+			ctx.JSON(http.StatusTooManyRequests, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrIdempotencyKeyReused) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -55,6 +142,21 @@ func (s *Server) createTransfer(ctx *gin.Context) {
 }
 
 func (s *Server) validAccount(ctx *gin.Context, accountId int64, currency string) (db.Account, bool) {
+	account, valid := s.existingAccount(ctx, accountId)
+	if !valid {
+		return account, false
+	}
+
+	if account.Currency != currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return account, false
+	}
+
+	return account, true
+}
+
+func (s *Server) existingAccount(ctx *gin.Context, accountId int64) (db.Account, bool) {
 	account, err := s.store.GetAccount(ctx, accountId)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -66,11 +168,59 @@ func (s *Server) validAccount(ctx *gin.Context, accountId int64, currency string
 		return account, false
 	}
 
-	if account.Currency != currency {
-		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	// system accounts are hidden from customers as if they did not exist
+	if account.Type == db.AccountTypeSystem {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
 		return account, false
 	}
 
 	return account, true
 }
+
+// resolvePayee returns the account in currency of the user with the given
+// username, or else verified email.
+// Every miss is reported the same way, so callers can't probe which users exist.
+func (s *Server) resolvePayee(ctx *gin.Context, username, email, currency string) (db.Account, bool) {
+	var user db.User
+	var err error
+	if username != "" {
+		user, err = s.store.GetUser(ctx, username)
+	} else {
+		user, err = s.store.GetUserByEmail(ctx, email)
+		if err == nil && !user.IsEmailVerified {
+			err = sql.ErrNoRows
+		}
+	}
+	if err != nil {
+		payeeError(ctx, err)
+		return db.Account{}, false
+	}
+
+	account, err := s.store.GetAccountByOwnerAndCurrency(ctx, db.GetAccountByOwnerAndCurrencyParams{
+		Owner:    user.Username,
+		Currency: currency,
+	})
+	if err != nil {
+		payeeError(ctx, err)
+		return account, false
+	}
+	return account, true
+}
+
+func payeeError(ctx *gin.Context, err error) {
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("payee not found")))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
+
+func countSet(values ...bool) int {
+	n := 0
+	for _, v := range values {
+		if v {
+			n++
+		}
+	}
+	return n
+}
@@ -115,14 +115,14 @@ func (s *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
-	accessToken, accessTokenPayload, err := s.tokenMaker.CreateToken(user.Username, s.config.AccessDuration)
+	accessToken, accessTokenPayload, err := s.tokenMaker.CreateToken(user.Username, user.Role, s.config.AccessDuration)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
 	// create refresh token
-	refreshToken, refreshTokenPayload, err := s.tokenMaker.CreateToken(user.Username, s.config.RefreshDuration)
+	refreshToken, refreshTokenPayload, err := s.tokenMaker.CreateToken(user.Username, user.Role, s.config.RefreshDuration)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
@@ -136,6 +136,7 @@ func (s *Server) loginUser(ctx *gin.Context) {
 		ClientIp:     ctx.ClientIP(),
 		IsBlocked:    false,
 		ExpiresAt:    refreshTokenPayload.ExpireAt,
+		FamilyID:     refreshTokenPayload.ID,
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -1,14 +1,22 @@
 package api
 
 import (
-	"github.com/dibrito/simple-bank/util"
+	"github.com/dibrito/simple-bank/currency"
+	"github.com/dibrito/simple-bank/val"
 	"github.com/go-playground/validator/v10"
 )
 
 var validCurrency validator.Func = func(fl validator.FieldLevel) bool {
-	if currency, ok := fl.Field().Interface().(string); ok {
-		// check currency is supported
-		return util.IsSupportedCurrency(currency)
+	if code, ok := fl.Field().Interface().(string); ok {
+		// check currency is known and enabled
+		return currency.IsSupported(code)
+	}
+	return false
+}
+
+var validTag validator.Func = func(fl validator.FieldLevel) bool {
+	if tag, ok := fl.Field().Interface().(string); ok {
+		return val.ValidateTag(tag) == nil
 	}
 	return false
 }
@@ -0,0 +1,104 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+)
+
+// Source lists the currencies the bank knows about. db.Store satisfies it.
+type Source interface {
+	ListCurrencies(ctx context.Context) ([]db.Currency, error)
+}
+
+// Registry is an in-memory cache of the currencies table.
+type Registry struct {
+	mu         sync.RWMutex
+	currencies map[string]db.Currency
+}
+
+// NewRegistry creates a registry holding the given currencies.
+func NewRegistry(currencies ...db.Currency) *Registry {
+	r := &Registry{}
+	r.set(currencies)
+	return r
+}
+
+func (r *Registry) set(currencies []db.Currency) {
+	m := make(map[string]db.Currency, len(currencies))
+	for _, c := range currencies {
+		m[c.Code] = c
+	}
+	r.mu.Lock()
+	r.currencies = m
+	r.mu.Unlock()
+}
+
+// Load replaces the cached currencies with the ones from src.
+func (r *Registry) Load(ctx context.Context, src Source) error {
+	currencies, err := src.ListCurrencies(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot list currencies: %w", err)
+	}
+	r.set(currencies)
+	return nil
+}
+
+// Get returns a currency by code, whether it is enabled or not.
+func (r *Registry) Get(code string) (db.Currency, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.currencies[code]
+	return c, ok
+}
+
+// IsSupported returns true if code is a known and enabled currency.
+func (r *Registry) IsSupported(code string) bool {
+	c, ok := r.Get(code)
+	return ok && c.Enabled
+}
+
+// List returns the enabled currencies ordered by code.
+func (r *Registry) List() []db.Currency {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]db.Currency, 0, len(r.currencies))
+	for _, c := range r.currencies {
+		if c.Enabled {
+			list = append(list, c)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// defaultRegistry starts with the currencies seeded by the migrations, so
+// validators work before Load is called, e.g. in unit tests.
+var defaultRegistry = NewRegistry(
+	db.Currency{Code: "BRL", NumericCode: 986, MinorUnit: 2, Enabled: true},
+	db.Currency{Code: "EUR", NumericCode: 978, MinorUnit: 2, Enabled: true},
+	db.Currency{Code: "USD", NumericCode: 840, MinorUnit: 2, Enabled: true},
+)
+
+// Load fills the default registry from src.
+func Load(ctx context.Context, src Source) error {
+	return defaultRegistry.Load(ctx, src)
+}
+
+// Get looks up a currency in the default registry.
+func Get(code string) (db.Currency, bool) {
+	return defaultRegistry.Get(code)
+}
+
+// IsSupported checks a currency against the default registry.
+func IsSupported(code string) bool {
+	return defaultRegistry.IsSupported(code)
+}
+
+// List returns the enabled currencies of the default registry.
+func List() []db.Currency {
+	return defaultRegistry.List()
+}
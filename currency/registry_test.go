@@ -0,0 +1,68 @@
+package currency
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	for _, code := range []string{util.EUR, util.USD, util.BRL} {
+		require.True(t, IsSupported(code))
+	}
+	require.False(t, IsSupported("XYZ"))
+}
+
+func TestRegistryLoad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		ListCurrencies(gomock.Any()).
+		Times(1).
+		Return([]db.Currency{
+			{Code: "USD", NumericCode: 840, MinorUnit: 2, Enabled: true},
+			{Code: "JPY", NumericCode: 392, MinorUnit: 0, Enabled: true},
+			{Code: "EUR", NumericCode: 978, MinorUnit: 2, Enabled: false},
+		}, nil)
+
+	r := NewRegistry()
+	require.NoError(t, r.Load(context.Background(), store))
+
+	jpy, ok := r.Get("JPY")
+	require.True(t, ok)
+	require.Equal(t, int32(0), jpy.MinorUnit)
+
+	// disabled currencies are known but not supported
+	_, ok = r.Get("EUR")
+	require.True(t, ok)
+	require.False(t, r.IsSupported("EUR"))
+
+	list := r.List()
+	require.Len(t, list, 2)
+	require.Equal(t, "JPY", list[0].Code)
+	require.Equal(t, "USD", list[1].Code)
+}
+
+func TestRegistryLoadError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		ListCurrencies(gomock.Any()).
+		Times(1).
+		Return(nil, sql.ErrConnDone)
+
+	r := NewRegistry(db.Currency{Code: "USD", MinorUnit: 2, Enabled: true})
+	require.Error(t, r.Load(context.Background(), store))
+	// the cache is kept on failure
+	require.True(t, r.IsSupported("USD"))
+}
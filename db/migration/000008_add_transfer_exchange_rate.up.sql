@@ -0,0 +1,13 @@
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" numeric;
+
+UPDATE "transfers" SET "to_amount" = "amount", "exchange_rate" = 1;
+
+ALTER TABLE "transfers" ALTER COLUMN "to_amount" SET NOT NULL;
+
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" SET NOT NULL;
+
+COMMENT ON COLUMN "transfers"."to_amount" IS 'amount credited, in the destination account currency';
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'rate applied to amount to get to_amount';
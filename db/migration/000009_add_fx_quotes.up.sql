@@ -0,0 +1,29 @@
+CREATE TABLE "fx_quotes" (
+  "id" uuid PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "from_currency" varchar NOT NULL,
+  "to_currency" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "to_amount" bigint NOT NULL,
+  "rate" numeric NOT NULL,
+  "spread" numeric NOT NULL,
+  "is_used" bool NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL
+);
+
+COMMENT ON COLUMN "fx_quotes"."amount" IS 'amount debited, in from_currency';
+
+COMMENT ON COLUMN "fx_quotes"."to_amount" IS 'amount credited, in to_currency';
+
+COMMENT ON COLUMN "fx_quotes"."rate" IS 'rate offered to the user, spread included';
+
+COMMENT ON COLUMN "fx_quotes"."spread" IS 'fraction taken off the market rate';
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
@@ -0,0 +1,23 @@
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  "numeric_code" int UNIQUE NOT NULL,
+  "minor_unit" int NOT NULL,
+  "enabled" bool NOT NULL DEFAULT true
+);
+
+ALTER TABLE "currencies" ADD CONSTRAINT "minor_unit_non_negative" CHECK ("minor_unit" >= 0);
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT ON COLUMN "currencies"."numeric_code" IS 'ISO 4217 numeric code';
+
+COMMENT ON COLUMN "currencies"."minor_unit" IS 'number of decimal places amounts are stored in';
+
+COMMENT ON COLUMN "currencies"."enabled" IS 'whether new accounts and transfers may use it';
+
+INSERT INTO "currencies" ("code", "numeric_code", "minor_unit") VALUES
+  ('BRL', 986, 2),
+  ('EUR', 978, 2),
+  ('USD', 840, 2);
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
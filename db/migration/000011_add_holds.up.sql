@@ -0,0 +1,32 @@
+ALTER TABLE "accounts" ADD COLUMN "held_amount" bigint NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'sum of pending holds, not available to spend';
+
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL
+);
+
+CREATE INDEX ON "holds" ("account_id");
+
+CREATE INDEX ON "holds" ("status", "expires_at");
+
+ALTER TABLE "holds" ADD CONSTRAINT "amount_positive" CHECK ("amount" > 0);
+
+COMMENT ON COLUMN "holds"."amount" IS 'amount reserved on account_id';
+
+COMMENT ON COLUMN "holds"."status" IS 'pending, captured or voided';
+
+COMMENT ON COLUMN "holds"."transfer_id" IS 'transfer that settled the hold once captured';
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,14 @@
+ALTER TABLE "transfers" ADD COLUMN "reversal_of_id" bigint;
+
+ALTER TABLE "transfers" ADD COLUMN "reversed_amount" bigint NOT NULL DEFAULT 0;
+
+-- partial refunds add up in reversed_amount, which can never exceed what was credited
+ALTER TABLE "transfers" ADD CONSTRAINT "reversed_amount_within_to_amount" CHECK ("reversed_amount" >= 0 AND "reversed_amount" <= "to_amount");
+
+CREATE INDEX ON "transfers" ("reversal_of_id");
+
+COMMENT ON COLUMN "transfers"."reversal_of_id" IS 'transfer this one refunds';
+
+COMMENT ON COLUMN "transfers"."reversed_amount" IS 'part of to_amount refunded so far';
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversal_of_id") REFERENCES "transfers" ("id");
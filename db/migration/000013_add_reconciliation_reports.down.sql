@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "reconciliation_reports";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "opening_balance";
@@ -0,0 +1,19 @@
+ALTER TABLE "accounts" ADD COLUMN "opening_balance" bigint NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "accounts"."opening_balance" IS 'balance the account was created with, before any entry';
+
+-- existing accounts are taken as reconciled up to now
+UPDATE "accounts" a SET "opening_balance" = a."balance" - COALESCE(
+  (SELECT SUM(e."amount") FROM "entries" e WHERE e."account_id" = a."id"), 0
+);
+
+CREATE TABLE "reconciliation_reports" (
+  "id" bigserial PRIMARY KEY,
+  "accounts_checked" bigint NOT NULL,
+  "transfers_checked" bigint NOT NULL,
+  "discrepancy_count" int NOT NULL,
+  "discrepancies" jsonb NOT NULL DEFAULT '[]',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "reconciliation_reports"."discrepancies" IS 'every inconsistency found, empty when the ledger balances';
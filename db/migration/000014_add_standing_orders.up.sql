@@ -0,0 +1,53 @@
+CREATE TABLE "standing_orders" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "schedule" varchar NOT NULL,
+  "next_run_at" timestamptz NOT NULL,
+  "end_at" timestamptz,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "standing_order_executions" (
+  "id" bigserial PRIMARY KEY,
+  "standing_order_id" bigint NOT NULL,
+  "transfer_id" bigint,
+  "status" varchar NOT NULL,
+  "failure_reason" varchar NOT NULL DEFAULT '',
+  "run_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "standing_orders" ("owner");
+
+CREATE INDEX ON "standing_orders" ("status", "next_run_at");
+
+CREATE INDEX ON "standing_order_executions" ("standing_order_id", "id");
+
+ALTER TABLE "standing_orders" ADD CONSTRAINT "amount_positive" CHECK ("amount" > 0);
+
+COMMENT ON COLUMN "standing_orders"."schedule" IS 'cron spec such as 0 9 1 * * or a descriptor such as @monthly or @every 168h';
+
+COMMENT ON COLUMN "standing_orders"."end_at" IS 'no run is scheduled after it, runs forever when null';
+
+COMMENT ON COLUMN "standing_orders"."status" IS 'active, paused, completed or cancelled';
+
+COMMENT ON COLUMN "standing_order_executions"."status" IS 'succeeded or failed';
+
+COMMENT ON COLUMN "standing_order_executions"."run_at" IS 'time the run was scheduled for';
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "standing_order_executions" ADD FOREIGN KEY ("standing_order_id") REFERENCES "standing_orders" ("id");
+
+ALTER TABLE "standing_order_executions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
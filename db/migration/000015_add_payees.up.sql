@@ -0,0 +1,27 @@
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint,
+  "username" varchar,
+  "currency" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "nickname");
+
+ALTER TABLE "payees" ADD CONSTRAINT "account_id_or_username" CHECK (("account_id" IS NULL) <> ("username" IS NULL));
+
+COMMENT ON COLUMN "payees"."account_id" IS 'account paid, set when username is null';
+
+COMMENT ON COLUMN "payees"."username" IS 'user paid into their account in currency, set when account_id is null';
+
+COMMENT ON COLUMN "payees"."currency" IS 'currency credited to the payee';
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "batch_items";
+
+DROP TABLE IF EXISTS "batches";
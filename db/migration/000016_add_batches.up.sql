@@ -0,0 +1,52 @@
+CREATE TABLE "batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "item_count" int NOT NULL,
+  "total_amount" bigint NOT NULL,
+  "succeeded_count" int NOT NULL DEFAULT 0,
+  "failed_count" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+CREATE TABLE "batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "row_number" int NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "failure_reason" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint
+);
+
+CREATE INDEX ON "batches" ("owner");
+
+CREATE UNIQUE INDEX ON "batch_items" ("batch_id", "row_number");
+
+ALTER TABLE "batch_items" ADD CONSTRAINT "amount_positive" CHECK ("amount" > 0);
+
+COMMENT ON COLUMN "batches"."status" IS 'pending, processing or completed';
+
+COMMENT ON COLUMN "batches"."total_amount" IS 'sum of the items amount';
+
+COMMENT ON COLUMN "batch_items"."row_number" IS 'position of the item in the request, from 1';
+
+COMMENT ON COLUMN "batch_items"."amount" IS 'must be positive';
+
+COMMENT ON COLUMN "batch_items"."status" IS 'pending, succeeded or failed';
+
+ALTER TABLE "batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "batches" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "batches" ("id");
+
+ALTER TABLE "batch_items" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,5 @@
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "tags";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "external_reference";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "description";
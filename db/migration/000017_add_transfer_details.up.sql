@@ -0,0 +1,16 @@
+ALTER TABLE "transfers" ADD COLUMN "description" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers" ADD COLUMN "external_reference" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers" ADD COLUMN "tags" varchar[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX ON "transfers" USING GIN ("tags");
+
+-- the 'simple' configuration doesn't stem, memos mix languages and names
+CREATE INDEX ON "transfers" USING GIN (to_tsvector('simple', "description"));
+
+COMMENT ON COLUMN "transfers"."description" IS 'memo shown to both parties';
+
+COMMENT ON COLUMN "transfers"."external_reference" IS 'identifier in the client system, e.g. an invoice number';
+
+COMMENT ON COLUMN "transfers"."tags" IS 'labels to filter the transfer history on';
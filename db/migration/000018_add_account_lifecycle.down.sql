@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS "owner_currency_type_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "account_type";
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "account_status";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "closed_at";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "nickname";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "type";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "status";
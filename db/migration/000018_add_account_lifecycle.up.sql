@@ -0,0 +1,23 @@
+ALTER TABLE "accounts" ADD COLUMN "status" varchar NOT NULL DEFAULT 'active';
+
+ALTER TABLE "accounts" ADD COLUMN "type" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" ADD COLUMN "nickname" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "accounts" ADD COLUMN "closed_at" timestamptz;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "account_status" CHECK ("status" IN ('active', 'frozen', 'closed'));
+
+ALTER TABLE "accounts" ADD CONSTRAINT "account_type" CHECK ("type" IN ('checking', 'savings'));
+
+-- closed accounts are kept for their entries, so they must not block a new
+-- account of the same currency and type
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_currency_key";
+
+CREATE UNIQUE INDEX "owner_currency_type_key" ON "accounts" ("owner", "currency", "type") WHERE "status" <> 'closed';
+
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen or closed. frozen accounts only receive money, closed accounts do nothing';
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking or savings';
+
+COMMENT ON COLUMN "accounts"."closed_at" IS 'set when the account is closed';
@@ -0,0 +1,41 @@
+-- ledger history is never deleted, so once interest was paid this migration
+-- cannot be reverted
+DO $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM "entries" e
+    JOIN "system_accounts" s ON s."account_id" = e."account_id"
+    WHERE s."purpose" = 'interest_expense'
+  ) OR EXISTS (
+    SELECT 1 FROM "transfers" t
+    JOIN "system_accounts" s ON s."account_id" IN (t."from_account_id", t."to_account_id")
+    WHERE s."purpose" = 'interest_expense'
+  ) THEN
+    RAISE EXCEPTION 'interest expense accounts have ledger history, refusing to delete them';
+  END IF;
+END $$;
+
+DROP TABLE IF EXISTS "interest_accruals";
+
+DROP TABLE IF EXISTS "interest_rates";
+
+CREATE TEMPORARY TABLE "dropped_accounts" AS
+SELECT "account_id" AS "id" FROM "system_accounts" WHERE "purpose" = 'interest_expense';
+
+DROP TABLE IF EXISTS "system_accounts";
+
+DELETE FROM "accounts" WHERE "id" IN (SELECT "id" FROM "dropped_accounts");
+
+DROP TABLE "dropped_accounts";
+
+COMMENT ON COLUMN "accounts"."owner" IS NULL;
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking or savings';
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "system_account_owner";
+
+ALTER TABLE "accounts" ALTER COLUMN "owner" SET NOT NULL;
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "account_type";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "account_type" CHECK ("type" IN ('checking', 'savings'));
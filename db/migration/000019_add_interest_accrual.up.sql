@@ -0,0 +1,87 @@
+CREATE TABLE "interest_rates" (
+  "id" bigserial PRIMARY KEY,
+  "account_type" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "annual_rate" numeric NOT NULL,
+  "effective_from" date NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate" numeric NOT NULL,
+  "amount" bigint NOT NULL,
+  "transfer_id" bigint,
+  "posted_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_rates" ADD CONSTRAINT "annual_rate_non_negative" CHECK ("annual_rate" >= 0);
+
+CREATE UNIQUE INDEX ON "interest_rates" ("account_type", "currency", "effective_from");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("account_id") WHERE "posted_at" IS NULL;
+
+COMMENT ON COLUMN "interest_rates"."annual_rate" IS 'fraction of the balance paid per year, e.g. 0.02 for 2%';
+
+COMMENT ON COLUMN "interest_rates"."effective_from" IS 'first day the rate applies, until a later rate takes over';
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'balance at the end of accrual_date';
+
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'interest earned on accrual_date, in minor units';
+
+COMMENT ON COLUMN "interest_accruals"."transfer_id" IS 'monthly credit that paid this accrual';
+
+COMMENT ON COLUMN "interest_accruals"."posted_at" IS 'set once the accrual is paid, or settled at zero';
+
+ALTER TABLE "interest_rates" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+INSERT INTO "interest_rates" ("account_type", "currency", "annual_rate", "effective_from") VALUES
+  ('savings', 'BRL', 0.10, '2023-01-01'),
+  ('savings', 'EUR', 0.02, '2023-01-01'),
+  ('savings', 'USD', 0.03, '2023-01-01');
+
+-- system accounts hold the bank's side of a movement. they belong to no
+-- user, so their owner is null, and each one is found by its purpose and
+-- currency in system_accounts
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "account_type";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "account_type" CHECK ("type" IN ('checking', 'savings', 'system'));
+
+ALTER TABLE "accounts" ALTER COLUMN "owner" DROP NOT NULL;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "system_account_owner" CHECK (("type" = 'system') = ("owner" IS NULL));
+
+COMMENT ON COLUMN "accounts"."owner" IS 'username, null for system accounts';
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings or system';
+
+CREATE TABLE "system_accounts" (
+  "purpose" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "account_id" bigint UNIQUE NOT NULL,
+  PRIMARY KEY ("purpose", "currency")
+);
+
+COMMENT ON COLUMN "system_accounts"."purpose" IS 'interest_expense';
+
+ALTER TABLE "system_accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "system_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+-- interest expense runs negative as interest is paid, so no overdraft limit applies
+INSERT INTO "accounts" ("owner", "balance", "currency", "type", "nickname", "overdraft_limit")
+SELECT NULL, 0, "code", 'system', 'interest expense', 9223372036854775807 FROM "currencies";
+
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'interest_expense', "currency", "id" FROM "accounts"
+WHERE "type" = 'system' AND "nickname" = 'interest expense';
@@ -0,0 +1,33 @@
+-- fees already charged stay in the ledger, the migration cannot be reverted
+-- after the first one
+DO $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM "entries" e
+    JOIN "system_accounts" s ON s."account_id" = e."account_id"
+    WHERE s."purpose" = 'fee_revenue'
+  ) OR EXISTS (
+    SELECT 1 FROM "transfers" t
+    JOIN "system_accounts" s ON s."account_id" IN (t."from_account_id", t."to_account_id")
+    WHERE s."purpose" = 'fee_revenue'
+  ) THEN
+    RAISE EXCEPTION 'fee revenue accounts have ledger history, refusing to delete them';
+  END IF;
+END $$;
+
+ALTER TABLE IF EXISTS "transfers" DROP CONSTRAINT IF EXISTS "fee_non_negative";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "fee";
+
+CREATE TEMPORARY TABLE "dropped_accounts" AS
+SELECT "account_id" AS "id" FROM "system_accounts" WHERE "purpose" = 'fee_revenue';
+
+DELETE FROM "system_accounts" WHERE "account_id" IN (SELECT "id" FROM "dropped_accounts");
+
+DELETE FROM "accounts" WHERE "id" IN (SELECT "id" FROM "dropped_accounts");
+
+DROP TABLE "dropped_accounts";
+
+COMMENT ON COLUMN "system_accounts"."purpose" IS 'interest_expense';
+
+DROP TABLE IF EXISTS "fee_schedules";
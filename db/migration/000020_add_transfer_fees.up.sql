@@ -0,0 +1,49 @@
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar NOT NULL,
+  "transfer_type" varchar NOT NULL,
+  "kind" varchar NOT NULL,
+  "flat_amount" bigint NOT NULL DEFAULT 0,
+  "percentage" numeric NOT NULL DEFAULT 0,
+  "min_amount" bigint NOT NULL DEFAULT 0,
+  "max_amount" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "fee_schedules" ADD CONSTRAINT "fee_kind" CHECK ("kind" IN ('flat', 'percentage'));
+
+ALTER TABLE "fee_schedules" ADD CONSTRAINT "fee_transfer_type" CHECK ("transfer_type" IN ('same_currency', 'cross_currency'));
+
+ALTER TABLE "fee_schedules" ADD CONSTRAINT "fee_amounts_non_negative" CHECK (
+  "flat_amount" >= 0 AND "percentage" >= 0 AND "min_amount" >= 0 AND ("max_amount" IS NULL OR "max_amount" >= "min_amount")
+);
+
+CREATE UNIQUE INDEX ON "fee_schedules" ("currency", "transfer_type");
+
+COMMENT ON COLUMN "fee_schedules"."transfer_type" IS 'same_currency or cross_currency';
+
+COMMENT ON COLUMN "fee_schedules"."kind" IS 'flat charges flat_amount, percentage charges percentage of the amount within min_amount and max_amount';
+
+COMMENT ON COLUMN "fee_schedules"."percentage" IS 'fraction of the amount, e.g. 0.01 for 1%';
+
+COMMENT ON COLUMN "fee_schedules"."max_amount" IS 'no cap when null';
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "transfers" ADD COLUMN "fee" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "transfers" ADD CONSTRAINT "fee_non_negative" CHECK ("fee" >= 0);
+
+COMMENT ON COLUMN "transfers"."fee" IS 'charged to the sender on top of amount and credited to the bank fee revenue account';
+
+COMMENT ON COLUMN "system_accounts"."purpose" IS 'fee_revenue or interest_expense';
+
+INSERT INTO "accounts" ("owner", "balance", "currency", "type", "nickname", "overdraft_limit")
+SELECT NULL, 0, "code", 'system', 'fee revenue', 9223372036854775807 FROM "currencies";
+
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'fee_revenue', "currency", "id" FROM "accounts"
+WHERE "type" = 'system' AND "nickname" = 'fee revenue';
+
+INSERT INTO "fee_schedules" ("currency", "transfer_type", "kind", "percentage", "min_amount", "max_amount")
+SELECT "code", 'cross_currency', 'percentage', 0.01, 100, 5000 FROM "currencies";
@@ -0,0 +1,37 @@
+CREATE TABLE "transfer_limits" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar,
+  "account_id" bigint,
+  "currency" varchar NOT NULL,
+  "max_single_amount" bigint NOT NULL,
+  "max_daily_amount" bigint NOT NULL,
+  "max_daily_count" int NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "limits_positive" CHECK (
+  "max_single_amount" > 0 AND "max_daily_amount" > 0 AND "max_daily_count" > 0
+);
+
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "account_limit_has_user" CHECK ("account_id" IS NULL OR "username" IS NOT NULL);
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("currency") WHERE "username" IS NULL;
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("username", "currency") WHERE "username" IS NOT NULL AND "account_id" IS NULL;
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("account_id") WHERE "account_id" IS NOT NULL;
+
+COMMENT ON COLUMN "transfer_limits"."username" IS 'null for the bank default in currency';
+
+COMMENT ON COLUMN "transfer_limits"."account_id" IS 'null for the limits on all accounts of username in currency';
+
+COMMENT ON COLUMN "transfer_limits"."max_daily_amount" IS 'outgoing sum allowed per UTC day';
+
+COMMENT ON COLUMN "transfer_limits"."max_daily_count" IS 'outgoing transfers allowed per UTC day';
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
@@ -0,0 +1,27 @@
+-- deposits, withdrawals and exchange legs stay in the ledger, so this cannot be
+-- reverted once there are any, even the legs posted when migrating up
+DO $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM "entries" e
+    JOIN "system_accounts" s ON s."account_id" = e."account_id"
+    WHERE s."purpose" IN ('cash', 'suspense', 'fx_position')
+  ) OR EXISTS (
+    SELECT 1 FROM "transfers" t
+    JOIN "system_accounts" s ON s."account_id" IN (t."from_account_id", t."to_account_id")
+    WHERE s."purpose" IN ('cash', 'suspense', 'fx_position')
+  ) THEN
+    RAISE EXCEPTION 'cash, suspense and fx position accounts have ledger history, refusing to delete them';
+  END IF;
+END $$;
+
+CREATE TEMPORARY TABLE "dropped_accounts" AS
+SELECT "account_id" AS "id" FROM "system_accounts" WHERE "purpose" IN ('cash', 'suspense', 'fx_position');
+
+DELETE FROM "system_accounts" WHERE "account_id" IN (SELECT "id" FROM "dropped_accounts");
+
+DELETE FROM "accounts" WHERE "id" IN (SELECT "id" FROM "dropped_accounts");
+
+DROP TABLE "dropped_accounts";
+
+COMMENT ON COLUMN "system_accounts"."purpose" IS 'fee_revenue or interest_expense';
@@ -0,0 +1,36 @@
+COMMENT ON COLUMN "system_accounts"."purpose" IS 'cash, fee_revenue, interest_expense, suspense or fx_position';
+
+INSERT INTO "accounts" ("owner", "balance", "currency", "type", "nickname", "overdraft_limit")
+SELECT NULL, 0, c."code", 'system', p."nickname", 9223372036854775807
+FROM "currencies" c
+CROSS JOIN (VALUES ('cash'), ('suspense'), ('fx position')) AS p ("nickname");
+
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT replace("nickname", ' ', '_'), "currency", "id" FROM "accounts"
+WHERE "type" = 'system' AND "nickname" IN ('cash', 'suspense', 'fx position');
+
+-- cross-currency transfers so far left each currency unbalanced, their
+-- exchange legs are posted to the fx position accounts
+INSERT INTO "entries" ("account_id", "amount", "created_at", "transfer_id")
+SELECT s."account_id", t."amount", t."created_at", t."id"
+FROM "transfers" t
+JOIN "accounts" fa ON fa."id" = t."from_account_id"
+JOIN "accounts" ta ON ta."id" = t."to_account_id"
+JOIN "system_accounts" s ON s."purpose" = 'fx_position' AND s."currency" = fa."currency"
+WHERE fa."currency" <> ta."currency";
+
+INSERT INTO "entries" ("account_id", "amount", "created_at", "transfer_id")
+SELECT s."account_id", -t."to_amount", t."created_at", t."id"
+FROM "transfers" t
+JOIN "accounts" fa ON fa."id" = t."from_account_id"
+JOIN "accounts" ta ON ta."id" = t."to_account_id"
+JOIN "system_accounts" s ON s."purpose" = 'fx_position' AND s."currency" = ta."currency"
+WHERE fa."currency" <> ta."currency";
+
+UPDATE "accounts" a SET "balance" = e."total"
+FROM (
+  SELECT "account_id", SUM("amount") AS "total" FROM "entries"
+  WHERE "account_id" IN (SELECT "account_id" FROM "system_accounts" WHERE "purpose" = 'fx_position')
+  GROUP BY "account_id"
+) e
+WHERE a."id" = e."account_id";
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "security_events";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "consumed_at";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "parent_id";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "family_id";
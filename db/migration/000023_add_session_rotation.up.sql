@@ -0,0 +1,40 @@
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+
+ALTER TABLE "sessions" ADD COLUMN "parent_id" uuid;
+
+ALTER TABLE "sessions" ADD COLUMN "consumed_at" timestamptz;
+
+-- each session so far is the start of its own family
+UPDATE "sessions" SET "family_id" = "id";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+CREATE INDEX ON "sessions" ("family_id");
+
+COMMENT ON COLUMN "sessions"."family_id" IS 'id of the login session the refresh token was rotated from';
+
+COMMENT ON COLUMN "sessions"."parent_id" IS 'session whose refresh token was exchanged for this one';
+
+COMMENT ON COLUMN "sessions"."consumed_at" IS 'set when the refresh token is exchanged, presenting it again blocks the family';
+
+ALTER TABLE "sessions" ADD FOREIGN KEY ("parent_id") REFERENCES "sessions" ("id");
+
+CREATE TABLE "security_events" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "kind" varchar NOT NULL,
+  "session_id" uuid,
+  "user_agent" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "security_events" ("username");
+
+COMMENT ON COLUMN "security_events"."kind" IS 'refresh_token_reused';
+
+COMMENT ON COLUMN "security_events"."session_id" IS 'session the event was detected on';
+
+ALTER TABLE "security_events" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "security_events" ADD FOREIGN KEY ("session_id") REFERENCES "sessions" ("id");
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "users" DROP CONSTRAINT IF EXISTS "user_role";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,5 @@
+ALTER TABLE "users" ADD COLUMN "role" varchar NOT NULL DEFAULT 'customer';
+
+ALTER TABLE "users" ADD CONSTRAINT "user_role" CHECK ("role" IN ('customer', 'support', 'admin', 'auditor'));
+
+COMMENT ON COLUMN "users"."role" IS 'customer, support, admin or auditor. it decides which RPCs the user may call';
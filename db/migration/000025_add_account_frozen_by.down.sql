@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "frozen_by_frozen";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "frozen_by";
@@ -0,0 +1,10 @@
+ALTER TABLE "accounts" ADD COLUMN "frozen_by" varchar;
+
+-- so far only owners could freeze their accounts
+UPDATE "accounts" SET "frozen_by" = "owner" WHERE "status" = 'frozen';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "frozen_by_frozen" CHECK ("frozen_by" IS NULL OR "status" = 'frozen');
+
+COMMENT ON COLUMN "accounts"."frozen_by" IS 'user who froze the account, null for a freeze by the bank. only they can lift it';
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("frozen_by") REFERENCES "users" ("username");
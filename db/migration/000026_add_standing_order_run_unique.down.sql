@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "standing_order_executions_run";
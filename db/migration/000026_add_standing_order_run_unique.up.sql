@@ -0,0 +1,8 @@
+-- keep the first execution of runs recorded more than once
+DELETE FROM "standing_order_executions" e
+USING "standing_order_executions" first
+WHERE e."standing_order_id" = first."standing_order_id"
+  AND e."run_at" = first."run_at"
+  AND e."id" > first."id";
+
+CREATE UNIQUE INDEX "standing_order_executions_run" ON "standing_order_executions" ("standing_order_id", "run_at");
@@ -2,9 +2,12 @@
 INSERT INTO accounts (
    owner,
   balance,
-  currency
+  currency,
+  opening_balance,
+  type,
+  nickname
 ) VALUES (
-  $1, $2,$3
+  sqlc.arg(owner)::varchar, sqlc.arg(balance), sqlc.arg(currency), sqlc.arg(balance), sqlc.arg(type), sqlc.arg(nickname)
 )
 RETURNING *;
 
@@ -12,6 +15,13 @@ RETURNING *;
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1;
 
+-- name: GetAccountByOwnerAndCurrency :one
+-- payments to a user are credited to their open checking account
+SELECT * FROM accounts
+WHERE owner = sqlc.arg(owner)::varchar AND currency = sqlc.arg(currency)
+  AND type = 'checking' AND status <> 'closed'
+LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1
@@ -19,10 +29,10 @@ FOR NO KEY UPDATE;
 
 -- name: ListAccounts :many
 SELECT * FROM accounts
-WHERE owner = $1
+WHERE owner = sqlc.arg(owner)::varchar
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateAccount :one
 UPDATE accounts SET balance = $2
@@ -34,6 +44,38 @@ UPDATE accounts SET balance = balance +  sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
--- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1;
+-- name: UpdateAccountNickname :one
+UPDATE accounts SET nickname = sqlc.arg(nickname)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateAccountStatus :one
+-- UpdateAccountStatus sets the status username asked for. It returns no rows
+-- if the account is closed, or frozen by someone other than username.
+UPDATE accounts
+SET
+  status = sqlc.arg(status),
+  frozen_by = CASE WHEN sqlc.arg(status)::varchar = 'frozen' THEN sqlc.arg(username)::varchar END
+WHERE id = sqlc.arg(id)
+  AND status <> 'closed'
+  AND (status <> 'frozen' OR frozen_by = sqlc.arg(username)::varchar)
+RETURNING *;
+
+-- name: CloseAccount :one
+-- accounts are never deleted, their entries are part of the ledger
+UPDATE accounts SET status = 'closed', closed_at = now(), frozen_by = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts SET overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: AddAccountHeldAmount :one
+UPDATE accounts SET held_amount = held_amount + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CountAccounts :one
+SELECT COUNT(*) FROM accounts;
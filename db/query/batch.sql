@@ -0,0 +1,61 @@
+-- name: CreateBatch :one
+INSERT INTO batches (
+  owner,
+  from_account_id,
+  currency,
+  item_count,
+  total_amount
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetBatch :one
+SELECT * FROM batches
+WHERE id = $1 LIMIT 1;
+
+-- name: UpdateBatchProgress :one
+UPDATE batches
+SET
+  status = sqlc.arg(status),
+  succeeded_count = succeeded_count + sqlc.arg(succeeded),
+  failed_count = failed_count + sqlc.arg(failed),
+  completed_at = sqlc.narg(completed_at)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateBatchItem :one
+INSERT INTO batch_items (
+  batch_id,
+  row_number,
+  to_account_id,
+  amount
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListBatchItems :many
+SELECT * FROM batch_items
+WHERE batch_id = $1
+ORDER BY row_number
+LIMIT $2
+OFFSET $3;
+
+-- name: ListPendingBatchItems :many
+SELECT * FROM batch_items
+WHERE batch_id = $1 AND status = 'pending'
+ORDER BY row_number
+LIMIT $2;
+
+-- name: UpdateBatchItemStatus :execrows
+-- UpdateBatchItemStatus records the outcome of a pending item. It changes
+-- no rows if the item is already recorded.
+UPDATE batch_items
+SET
+  status = sqlc.arg(status),
+  failure_reason = sqlc.arg(failure_reason),
+  transfer_id = sqlc.narg(transfer_id)
+WHERE id = sqlc.arg(id)
+  AND batch_id = sqlc.arg(batch_id)
+  AND status = 'pending';
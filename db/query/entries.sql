@@ -1,9 +1,10 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3
 )
 RETURNING *;
 
@@ -12,8 +13,19 @@ SELECT * FROM entries
 WHERE id = $1 LIMIT 1;
 
 -- name: ListEntries :many
-SELECT * FROM entries 
-WHERE account_id = $1
-ORDER BY created_at 
-LIMIT $2
-OFFSET $3;
\ No newline at end of file
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+  AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+  AND (sqlc.narg(direction)::text IS NULL
+    OR (sqlc.narg(direction) = 'debit' AND amount < 0)
+    OR (sqlc.narg(direction) = 'credit' AND amount > 0))
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR abs(amount) >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR abs(amount) <= sqlc.narg(max_amount))
+  AND (sqlc.narg(counterparty_id)::bigint IS NULL OR transfer_id IN (
+    SELECT id FROM transfers
+    WHERE from_account_id = sqlc.narg(counterparty_id) OR to_account_id = sqlc.narg(counterparty_id)))
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (sqlc.narg(cursor_created_at), sqlc.narg(cursor_id)::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_size);
@@ -0,0 +1,3 @@
+-- name: GetFeeSchedule :one
+SELECT * FROM fee_schedules
+WHERE currency = $1 AND transfer_type = $2 LIMIT 1;
@@ -0,0 +1,31 @@
+-- name: CreateFxQuote :one
+INSERT INTO fx_quotes (
+  id,
+  username,
+  from_account_id,
+  to_account_id,
+  from_currency,
+  to_currency,
+  amount,
+  to_amount,
+  rate,
+  spread,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+RETURNING *;
+
+-- name: UseFxQuote :one
+UPDATE fx_quotes
+SET
+  is_used = TRUE
+WHERE
+  id = @id
+  AND username = @username
+  AND from_account_id = @from_account_id
+  AND to_account_id = @to_account_id
+  AND amount = @amount
+  AND is_used = FALSE
+  AND expires_at > NOW()
+RETURNING *;
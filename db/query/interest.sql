@@ -0,0 +1,69 @@
+-- name: GetInterestRate :one
+-- the rate in force on a day is the latest one effective by then
+SELECT * FROM interest_rates
+WHERE account_type = $1 AND currency = $2 AND effective_from <= $3
+ORDER BY effective_from DESC
+LIMIT 1;
+
+-- name: ListAccountsToAccrue :many
+-- accounts with a rate for their type and currency and no accrual for the
+-- day yet, with their balance at the end of the day rebuilt from the entries
+SELECT a.id, a.type, a.currency,
+  (a.opening_balance + COALESCE(SUM(e.amount), 0))::bigint AS balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at < sqlc.arg(day_end)
+WHERE a.status <> 'closed'
+  AND a.created_at < sqlc.arg(day_end)
+  AND EXISTS (
+    SELECT 1 FROM interest_rates r
+    WHERE r.account_type = a.type AND r.currency = a.currency
+      AND r.effective_from <= sqlc.arg(accrual_date)
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM interest_accruals ia
+    WHERE ia.account_id = a.id AND ia.accrual_date = sqlc.arg(accrual_date)
+  )
+GROUP BY a.id
+ORDER BY a.id
+LIMIT sqlc.arg(page_size);
+
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+  account_id,
+  accrual_date,
+  balance,
+  annual_rate,
+  amount
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ListUnpostedInterest :many
+-- interest accrued before a day and not paid yet, per open account.
+-- accounts are paged by id, so one that keeps failing is not retried forever
+SELECT ia.account_id, a.owner, a.currency, SUM(ia.amount)::bigint AS amount,
+  MIN(ia.accrual_date)::date AS first_accrual_date
+FROM interest_accruals ia
+JOIN accounts a ON a.id = ia.account_id
+WHERE ia.posted_at IS NULL
+  AND ia.accrual_date < sqlc.arg(before)
+  AND ia.account_id > sqlc.arg(after_account_id)
+  AND a.status <> 'closed'
+GROUP BY ia.account_id, a.owner, a.currency
+ORDER BY ia.account_id
+LIMIT sqlc.arg(page_size);
+
+-- name: MarkInterestAccrualsPosted :exec
+UPDATE interest_accruals
+SET transfer_id = sqlc.narg(transfer_id), posted_at = now()
+WHERE account_id = sqlc.arg(account_id)
+  AND posted_at IS NULL
+  AND accrual_date < sqlc.arg(before);
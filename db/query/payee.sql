@@ -0,0 +1,41 @@
+-- name: CreatePayee :one
+INSERT INTO payees (
+  owner,
+  nickname,
+  account_id,
+  username,
+  currency
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetPayee :one
+SELECT * FROM payees
+WHERE id = $1 LIMIT 1;
+
+-- name: GetNewestPayeeOfAccount :one
+-- GetNewestPayeeOfAccount returns the payee owner saved last that pays into
+-- an account, by its id or by its owner and currency.
+SELECT * FROM payees
+WHERE owner = sqlc.arg(owner)
+  AND (account_id = sqlc.arg(account_id)
+    OR (username = sqlc.arg(account_owner)::varchar AND currency = sqlc.arg(currency)))
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListPayees :many
+SELECT * FROM payees
+WHERE owner = $1
+ORDER BY nickname
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdatePayeeNickname :one
+UPDATE payees SET nickname = sqlc.arg(nickname)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeletePayee :exec
+DELETE FROM payees
+WHERE id = $1;
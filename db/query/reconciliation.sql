@@ -0,0 +1,71 @@
+-- name: ListAccountBalanceMismatches :many
+SELECT
+  a.id,
+  a.balance,
+  (a.opening_balance + COALESCE(SUM(e.amount), 0))::bigint AS entries_balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> a.opening_balance + COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
+-- name: ListHeldAmountMismatches :many
+SELECT
+  a.id,
+  a.held_amount,
+  COALESCE(SUM(h.amount), 0)::bigint AS pending_holds
+FROM accounts a
+LEFT JOIN holds h ON h.account_id = a.id AND h.status = 'pending'
+GROUP BY a.id
+HAVING a.held_amount <> COALESCE(SUM(h.amount), 0)
+ORDER BY a.id;
+
+-- name: ListTrialBalance :many
+-- ListTrialBalance sums all entries per currency. Every movement is posted
+-- against a system account, so each total must be zero.
+SELECT a.currency, SUM(e.amount)::bigint AS total
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+GROUP BY a.currency
+ORDER BY a.currency;
+
+-- name: ListUnbalancedTransfers :many
+SELECT
+  t.id,
+  t.amount,
+  t.to_amount,
+  t.fee,
+  fa.currency <> ta.currency AS cross_currency,
+  COUNT(e.id) AS entry_count,
+  COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.from_account_id), 0)::bigint AS debited,
+  COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.to_account_id), 0)::bigint AS credited,
+  COALESCE(SUM(e.amount) FILTER (WHERE s.purpose = 'fee_revenue'), 0)::bigint AS fee_collected
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+LEFT JOIN entries e ON e.transfer_id = t.id
+LEFT JOIN system_accounts s ON s.account_id = e.account_id
+GROUP BY t.id, fa.currency, ta.currency
+HAVING COUNT(e.id) <> 2
+    + CASE WHEN t.fee > 0 THEN 1 ELSE 0 END
+    + CASE WHEN fa.currency <> ta.currency THEN 2 ELSE 0 END
+  OR COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.from_account_id), 0) <> -(t.amount + t.fee)
+  OR COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.to_account_id), 0) <> t.to_amount
+  OR COALESCE(SUM(e.amount) FILTER (WHERE s.purpose = 'fee_revenue'), 0) <> t.fee
+ORDER BY t.id;
+
+-- name: CreateReconciliationReport :one
+INSERT INTO reconciliation_reports (
+  accounts_checked,
+  transfers_checked,
+  discrepancy_count,
+  discrepancies
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetLatestReconciliationReport :one
+SELECT * FROM reconciliation_reports
+ORDER BY id DESC
+LIMIT 1;
@@ -6,12 +6,45 @@ INSERT INTO sessions (
   user_agent,
   client_ip,
   is_blocked,
-  expires_at
+  expires_at,
+  family_id,
+  parent_id
 ) VALUES (
-  $1, $2,$3,$4,$5,$6,$7
+  $1, $2,$3,$4,$5,$6,$7,$8,$9
 )
 RETURNING *;
 
 -- name: GetSession :one
 SELECT * FROM sessions
-WHERE id = $1 LIMIT 1;
\ No newline at end of file
+WHERE id = $1 LIMIT 1;
+
+-- name: ConsumeSession :one
+-- ConsumeSession marks the refresh token of a session as exchanged.
+-- It returns no rows if it already was.
+UPDATE sessions
+SET consumed_at = now()
+WHERE id = $1 AND consumed_at IS NULL
+RETURNING *;
+
+-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1
+RETURNING *;
+
+-- name: BlockSessionFamily :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1;
+
+-- name: ListSessionsByUser :many
+-- ListSessionsByUser returns the sessions of username that can still renew
+-- an access token, the most recent first.
+SELECT * FROM sessions
+WHERE username = $1
+  AND is_blocked = false
+  AND consumed_at IS NULL
+  AND expires_at > now()
+ORDER BY created_at DESC
+LIMIT $2
+OFFSET $3;
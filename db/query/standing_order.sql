@@ -0,0 +1,63 @@
+-- name: CreateStandingOrder :one
+INSERT INTO standing_orders (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  schedule,
+  next_run_at,
+  end_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetStandingOrder :one
+SELECT * FROM standing_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: ListStandingOrders :many
+SELECT * FROM standing_orders
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListDueStandingOrders :many
+SELECT * FROM standing_orders
+WHERE status = 'active' AND next_run_at <= NOW()
+ORDER BY next_run_at
+LIMIT $1;
+
+-- name: UpdateStandingOrder :one
+UPDATE standing_orders
+SET
+  amount = COALESCE(sqlc.narg(amount), amount),
+  schedule = COALESCE(sqlc.narg(schedule), schedule),
+  next_run_at = COALESCE(sqlc.narg(next_run_at), next_run_at),
+  end_at = COALESCE(sqlc.narg(end_at), end_at),
+  status = COALESCE(sqlc.narg(status), status)
+WHERE
+  id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateStandingOrderExecution :one
+-- CreateStandingOrderExecution returns no rows if the run is already recorded.
+INSERT INTO standing_order_executions (
+  standing_order_id,
+  transfer_id,
+  status,
+  failure_reason,
+  run_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (standing_order_id, run_at) DO NOTHING
+RETURNING *;
+
+-- name: ListStandingOrderExecutions :many
+SELECT * FROM standing_order_executions
+WHERE standing_order_id = $1
+ORDER BY id DESC
+LIMIT $2;
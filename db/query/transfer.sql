@@ -2,20 +2,51 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  to_amount,
+  exchange_rate,
+  reversal_of_id,
+  description,
+  external_reference,
+  tags,
+  fee
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListTransfers :many
 SELECT * FROM transfers
-WHERE 
-    from_account_id = $1 OR
-    to_account_id = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4;
\ No newline at end of file
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+  AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+  AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+  AND (sqlc.narg(direction)::text IS NULL
+    OR (sqlc.narg(direction) = 'debit' AND from_account_id = sqlc.arg(account_id))
+    OR (sqlc.narg(direction) = 'credit' AND to_account_id = sqlc.arg(account_id)))
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+  AND (sqlc.narg(counterparty_id)::bigint IS NULL
+    OR from_account_id = sqlc.narg(counterparty_id) OR to_account_id = sqlc.narg(counterparty_id))
+  AND (sqlc.narg(tag)::varchar IS NULL OR tags @> ARRAY[sqlc.narg(tag)::varchar])
+  AND (sqlc.narg(search)::text IS NULL
+    OR to_tsvector('simple', description) @@ plainto_tsquery('simple', sqlc.narg(search)))
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (sqlc.narg(cursor_created_at), sqlc.narg(cursor_id)::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: AddTransferReversedAmount :one
+UPDATE transfers SET reversed_amount = reversed_amount + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CountTransfers :one
+SELECT COUNT(*) FROM transfers;
@@ -0,0 +1,47 @@
+-- name: CreateTransferLimit :one
+INSERT INTO transfer_limits (
+  username,
+  account_id,
+  currency,
+  max_single_amount,
+  max_daily_amount,
+  max_daily_count
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ListTransferLimits :many
+-- ListTransferLimits returns the rules on transfers in currency from an
+-- account of username: the bank default, the user rule and the account rule.
+SELECT * FROM transfer_limits
+WHERE currency = sqlc.arg(currency)
+  AND (username IS NULL
+    OR (username = sqlc.arg(username)::varchar AND (account_id IS NULL OR account_id = sqlc.arg(account_id)::bigint)))
+ORDER BY id;
+
+-- name: UpdateTransferLimit :one
+UPDATE transfer_limits
+SET
+  max_single_amount = $2,
+  max_daily_amount = $3,
+  max_daily_count = $4,
+  updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetDailyTransferUsage :one
+-- GetDailyTransferUsage sums the transfers sent since the start of the day
+-- from the accounts of owner in currency, and from one of them.
+-- Reversals are not counted, they are not initiated by the owner.
+SELECT
+  COALESCE(SUM(t.amount), 0)::bigint AS user_amount,
+  COUNT(t.id) AS user_count,
+  COALESCE(SUM(t.amount) FILTER (WHERE t.from_account_id = sqlc.arg(account_id)), 0)::bigint AS account_amount,
+  COUNT(t.id) FILTER (WHERE t.from_account_id = sqlc.arg(account_id)) AS account_count
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = sqlc.arg(owner)::varchar
+  AND a.currency = sqlc.arg(currency)
+  AND t.created_at >= sqlc.arg(since)
+  AND t.reversal_of_id IS NULL;
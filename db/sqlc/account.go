@@ -0,0 +1,51 @@
+package db
+
+import "errors"
+
+const (
+	AccountStatusActive = "active"
+	AccountStatusFrozen = "frozen"
+	AccountStatusClosed = "closed"
+)
+
+const (
+	AccountTypeChecking = "checking"
+	AccountTypeSavings  = "savings"
+	// AccountTypeSystem accounts hold the bank's side of every movement.
+	// They have no owner and are found by purpose and currency.
+	AccountTypeSystem = "system"
+)
+
+// Purposes of the system accounts, there is one per purpose and currency.
+const (
+	// SystemAccountCash is the cash deposited and withdrawn.
+	SystemAccountCash = "cash"
+	// SystemAccountFeeRevenue collects transfer fees.
+	SystemAccountFeeRevenue = "fee_revenue"
+	// SystemAccountInterestExpense pays interest on savings.
+	SystemAccountInterestExpense = "interest_expense"
+	// SystemAccountSuspense parks money that cannot be posted yet.
+	SystemAccountSuspense = "suspense"
+	// SystemAccountFXPosition balances each currency of a cross-currency
+	// transfer: it takes the amount sent and pays the amount received.
+	SystemAccountFXPosition = "fx_position"
+)
+
+var (
+	// ErrAccountFrozen is returned when debiting a frozen account.
+	ErrAccountFrozen = errors.New("account is frozen")
+	// ErrAccountClosed is returned for any money movement on a closed account.
+	ErrAccountClosed = errors.New("account is closed")
+)
+
+// checkAccountStatus tells whether an account can take a movement of amount:
+// frozen accounts can only be credited and closed accounts not at all.
+func checkAccountStatus(account Account, amount int64) error {
+	switch {
+	case account.Status == AccountStatusClosed:
+		return ErrAccountClosed
+	case account.Status == AccountStatusFrozen && amount < 0:
+		return ErrAccountFrozen
+	}
+	return nil
+}
@@ -12,7 +12,7 @@ import (
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts SET balance = balance +  $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by
 `
 
 type AddAccountBalanceParams struct {
@@ -29,29 +29,119 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
 	)
 	return i, err
 }
 
+const addAccountHeldAmount = `-- name: AddAccountHeldAmount :one
+UPDATE accounts SET held_amount = held_amount + $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by
+`
+
+type AddAccountHeldAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, addAccountHeldAmount, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const closeAccount = `-- name: CloseAccount :one
+UPDATE accounts SET status = 'closed', closed_at = now(), frozen_by = NULL
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by
+`
+
+// accounts are never deleted, their entries are part of the ledger
+func (q *Queries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	row := q.db.QueryRowContext(ctx, closeAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const countAccounts = `-- name: CountAccounts :one
+SELECT COUNT(*) FROM accounts
+`
+
+func (q *Queries) CountAccounts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAccounts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
    owner,
   balance,
-  currency
+  currency,
+  opening_balance,
+  type,
+  nickname
 ) VALUES (
-  $1, $2,$3
+  $1::varchar, $2, $3, $2, $4, $5
 )
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by
 `
 
 type CreateAccountParams struct {
 	Owner    string `json:"owner"`
 	Balance  int64  `json:"balance"`
 	Currency string `json:"currency"`
+	Type     string `json:"type"`
+	Nickname string `json:"nickname"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.Type,
+		arg.Nickname,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -59,22 +149,20 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
 	)
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1
-`
-
-func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteAccount, id)
-	return err
-}
-
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -87,12 +175,54 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const getAccountByOwnerAndCurrency = `-- name: GetAccountByOwnerAndCurrency :one
+SELECT id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by FROM accounts
+WHERE owner = $1::varchar AND currency = $2
+  AND type = 'checking' AND status <> 'closed'
+LIMIT 1
+`
+
+type GetAccountByOwnerAndCurrencyParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+}
+
+// payments to a user are credited to their open checking account
+func (q *Queries) GetAccountByOwnerAndCurrency(ctx context.Context, arg GetAccountByOwnerAndCurrencyParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByOwnerAndCurrency, arg.Owner, arg.Currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
@@ -106,13 +236,21 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
-WHERE owner = $1
+SELECT id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by FROM accounts
+WHERE owner = $1::varchar
 ORDER BY id
 LIMIT $2
 OFFSET $3
@@ -139,6 +277,14 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.OverdraftLimit,
+			&i.HeldAmount,
+			&i.OpeningBalance,
+			&i.Status,
+			&i.Type,
+			&i.Nickname,
+			&i.ClosedAt,
+			&i.FrozenBy,
 		); err != nil {
 			return nil, err
 		}
@@ -156,7 +302,7 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts SET balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by
 `
 
 type UpdateAccountParams struct {
@@ -173,6 +319,118 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const updateAccountNickname = `-- name: UpdateAccountNickname :one
+UPDATE accounts SET nickname = $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by
+`
+
+type UpdateAccountNicknameParams struct {
+	Nickname string `json:"nickname"`
+	ID       int64  `json:"id"`
+}
+
+func (q *Queries) UpdateAccountNickname(ctx context.Context, arg UpdateAccountNicknameParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountNickname, arg.Nickname, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const updateAccountOverdraftLimit = `-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts SET overdraft_limit = $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by
+`
+
+type UpdateAccountOverdraftLimitParams struct {
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	ID             int64 `json:"id"`
+}
+
+func (q *Queries) UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountOverdraftLimit, arg.OverdraftLimit, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET
+  status = $1,
+  frozen_by = CASE WHEN $1::varchar = 'frozen' THEN $2::varchar END
+WHERE id = $3
+  AND status <> 'closed'
+  AND (status <> 'frozen' OR frozen_by = $2::varchar)
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, held_amount, opening_balance, status, type, nickname, closed_at, frozen_by
+`
+
+type UpdateAccountStatusParams struct {
+	Status   string `json:"status"`
+	Username string `json:"username"`
+	ID       int64  `json:"id"`
+}
+
+// UpdateAccountStatus sets the status username asked for. It returns no rows
+// if the account is closed, or frozen by someone other than username.
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus, arg.Status, arg.Username, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.OpeningBalance,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.ClosedAt,
+		&i.FrozenBy,
 	)
 	return i, err
 }
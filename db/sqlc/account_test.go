@@ -16,14 +16,17 @@ func createTestAccount(t *testing.T) Account {
 		Owner:    u.Username,
 		Balance:  util.RandonMoney(),
 		Currency: util.RandonCurrency(),
+		Type:     AccountTypeChecking,
 	}
 	account1, err := testQueries.CreateAccount(context.Background(), arg)
 	// require stops the test if fails
 	require.NoError(t, err)
 	require.NotEmpty(t, account1)
-	require.Equal(t, arg.Owner, account1.Owner)
+	require.Equal(t, arg.Owner, account1.Owner.String)
 	require.Equal(t, arg.Balance, account1.Balance)
 	require.Equal(t, arg.Currency, account1.Currency)
+	require.Equal(t, AccountStatusActive, account1.Status)
+	require.Equal(t, AccountTypeChecking, account1.Type)
 
 	require.NotZero(t, account1.ID)
 	require.NotZero(t, account1.CreatedAt)
@@ -48,6 +51,16 @@ func TestGetAccount(t *testing.T) {
 	require.WithinDuration(t, acc1.CreatedAt, acc2.CreatedAt, time.Second)
 }
 
+func TestGetAccountByOwnerAndCurrency(t *testing.T) {
+	want := createTestAccount(t)
+	got, err := testQueries.GetAccountByOwnerAndCurrency(context.Background(), GetAccountByOwnerAndCurrencyParams{
+		Owner:    want.Owner.String,
+		Currency: want.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, want.ID, got.ID)
+}
+
 func TestUpdateAccount(t *testing.T) {
 	acc1 := createTestAccount(t)
 
@@ -68,14 +81,169 @@ func TestUpdateAccount(t *testing.T) {
 	require.WithinDuration(t, acc1.CreatedAt, acc2.CreatedAt, time.Second)
 }
 
-func TestDeleteAccount(t *testing.T) {
+// createTestSavingsAccount opens a savings account next to account, with the
+// same owner and currency.
+func createTestSavingsAccount(t *testing.T, account Account) Account {
+	savings, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account.Owner.String,
+		Currency: account.Currency,
+		Type:     AccountTypeSavings,
+		Nickname: util.RandomString(6),
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeSavings, savings.Type)
+	return savings
+}
+
+func setTestAccountStatus(t *testing.T, account Account, status string) Account {
+	account, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:   status,
+		Username: account.Owner.String,
+		ID:       account.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, status, account.Status)
+	return account
+}
+
+func TestUpdateAccountStatusFrozenBy(t *testing.T) {
+	account := setTestAccountStatus(t, createTestAccount(t), AccountStatusFrozen)
+	require.Equal(t, account.Owner, account.FrozenBy)
+
+	// a freeze is lifted only by whoever put it in place
+	_, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:   AccountStatusActive,
+		Username: util.RandomOwner(),
+		ID:       account.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	account = setTestAccountStatus(t, account, AccountStatusActive)
+	require.False(t, account.FrozenBy.Valid)
+
+	// closed accounts stay closed
+	store := NewStore(testDB)
+	closed, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:        account.ID,
+		SweepToAccountID: createTestSavingsAccount(t, account).ID,
+	})
+	require.NoError(t, err)
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:   AccountStatusActive,
+		Username: account.Owner.String,
+		ID:       closed.Account.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateAccountNickname(t *testing.T) {
 	acc1 := createTestAccount(t)
-	err := testQueries.DeleteAccount(context.Background(), acc1.ID)
+	nickname := util.RandomString(8)
+
+	acc2, err := testQueries.UpdateAccountNickname(context.Background(), UpdateAccountNicknameParams{
+		Nickname: nickname,
+		ID:       acc1.ID,
+	})
 	require.NoError(t, err)
+	require.Equal(t, nickname, acc2.Nickname)
+}
 
-	acc2, err := testQueries.GetAccount(context.Background(), acc1.ID)
-	require.Error(t, err, sql.ErrNoRows)
-	require.Empty(t, acc2)
+func TestCloseAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+	checking := createTestAccount(t)
+	savings := createTestSavingsAccount(t, checking)
+
+	// make sure there is something to sweep
+	payer := setTestOverdraftLimit(t, createTestAccount(t), 10)
+	funding, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: payer.ID,
+		ToAccountID:   checking.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	checking = funding.ToAccount
+
+	// an account with money and nowhere to put it stays open
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: checking.ID})
+	require.ErrorIs(t, err, ErrAccountNotEmpty)
+
+	result, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:        checking.ID,
+		SweepToAccountID: savings.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+	require.True(t, result.Account.ClosedAt.Valid)
+	require.Zero(t, result.Account.Balance)
+	require.NotNil(t, result.Sweep)
+	require.Equal(t, checking.Balance, result.Sweep.Transfer.Amount)
+	require.Equal(t, savings.Balance+checking.Balance, result.Sweep.ToAccount.Balance)
+
+	// the account is kept and can be found, but takes no more movements
+	closed, err := testQueries.GetAccount(context.Background(), checking.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, closed.Status)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: savings.ID,
+		ToAccountID:   checking.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: checking.ID})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	// a closed account does not take the owner's currency slot
+	reopened, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    checking.Owner.String,
+		Currency: checking.Currency,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, checking.ID, reopened.ID)
+}
+
+func TestCloseAccountTxInvalidSweep(t *testing.T) {
+	store := NewStore(testDB)
+	account := createTestAccount(t)
+	other := createTestAccount(t)
+
+	_, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:        account.ID,
+		SweepToAccountID: other.ID,
+	})
+	require.ErrorIs(t, err, ErrInvalidSweepAccount)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:        account.ID,
+		SweepToAccountID: account.ID,
+	})
+	require.ErrorIs(t, err, ErrInvalidSweepAccount)
+}
+
+func TestTransferTxFrozenAccount(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 10)
+	account2 := setTestOverdraftLimit(t, createTestAccount(t), 10)
+	account1 = setTestAccountStatus(t, account1, AccountStatusFrozen)
+
+	// debits are refused
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	// credits are still accepted
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance+1, result.ToAccount.Balance)
 }
 
 func TestListAccount(t *testing.T) {
@@ -85,7 +253,7 @@ func TestListAccount(t *testing.T) {
 	}
 
 	accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{
-		Owner:  lastAccount.Owner,
+		Owner:  lastAccount.Owner.String,
 		Limit:  5,
 		Offset: 0,
 	})
@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestBatch(t *testing.T, store Store, from Account, to ...Account) CreateBatchTxResult {
+	arg := CreateBatchTxParams{
+		Owner:         from.Owner.String,
+		FromAccountID: from.ID,
+		Currency:      from.Currency,
+		AfterCreate:   func(batch Batch) error { return nil },
+	}
+	for i, account := range to {
+		arg.Items = append(arg.Items, BatchItemParams{ToAccountID: account.ID, Amount: int64(10 * (i + 1))})
+	}
+
+	result, err := store.CreateBatchTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	batch := result.Batch
+	require.NotZero(t, batch.ID)
+	require.Equal(t, arg.Owner, batch.Owner)
+	require.Equal(t, arg.FromAccountID, batch.FromAccountID)
+	require.Equal(t, BatchStatusPending, batch.Status)
+	require.Equal(t, int32(len(to)), batch.ItemCount)
+	require.Zero(t, batch.SucceededCount)
+	require.Zero(t, batch.FailedCount)
+	require.False(t, batch.CompletedAt.Valid)
+
+	var total int64
+	require.Len(t, result.Items, len(to))
+	for i, item := range result.Items {
+		require.Equal(t, batch.ID, item.BatchID)
+		require.Equal(t, int32(i+1), item.RowNumber)
+		require.Equal(t, arg.Items[i].ToAccountID, item.ToAccountID)
+		require.Equal(t, arg.Items[i].Amount, item.Amount)
+		require.Equal(t, BatchItemStatusPending, item.Status)
+		require.False(t, item.TransferID.Valid)
+		total += item.Amount
+	}
+	require.Equal(t, total, batch.TotalAmount)
+	return result
+}
+
+func TestCreateBatchTx(t *testing.T) {
+	store := NewStore(testDB)
+	createTestBatch(t, store, createTestAccount(t), createTestAccount(t), createTestAccount(t))
+}
+
+func TestCreateBatchTxRollback(t *testing.T) {
+	store := NewStore(testDB)
+	from := createTestAccount(t)
+	errEnqueue := errors.New("enqueue failed")
+
+	var batchID int64
+	_, err := store.CreateBatchTx(context.Background(), CreateBatchTxParams{
+		Owner:         from.Owner.String,
+		FromAccountID: from.ID,
+		Currency:      from.Currency,
+		Items:         []BatchItemParams{{ToAccountID: createTestAccount(t).ID, Amount: 10}},
+		AfterCreate: func(batch Batch) error {
+			batchID = batch.ID
+			return errEnqueue
+		},
+	})
+	require.ErrorIs(t, err, errEnqueue)
+
+	// the batch is not kept when its task could not be enqueued
+	_, err = testQueries.GetBatch(context.Background(), batchID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestBatchProgress(t *testing.T) {
+	store := NewStore(testDB)
+	result := createTestBatch(t, store, createTestAccount(t), createTestAccount(t), createTestAccount(t))
+	batch := result.Batch
+
+	rows, err := testQueries.UpdateBatchItemStatus(context.Background(), UpdateBatchItemStatusParams{
+		ID:            result.Items[0].ID,
+		BatchID:       batch.ID,
+		Status:        BatchItemStatusFailed,
+		FailureReason: "insufficient funds",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	pending, err := testQueries.ListPendingBatchItems(context.Background(), ListPendingBatchItemsParams{
+		BatchID: batch.ID,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, result.Items[1].ID, pending[0].ID)
+
+	batch, err = testQueries.UpdateBatchProgress(context.Background(), UpdateBatchProgressParams{
+		ID:     batch.ID,
+		Status: BatchStatusProcessing,
+		Failed: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, BatchStatusProcessing, batch.Status)
+	require.Equal(t, int32(1), batch.FailedCount)
+
+	// counts add up across updates
+	batch, err = testQueries.UpdateBatchProgress(context.Background(), UpdateBatchProgressParams{
+		ID:          batch.ID,
+		Status:      BatchStatusCompleted,
+		Succeeded:   1,
+		CompletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, BatchStatusCompleted, batch.Status)
+	require.Equal(t, int32(1), batch.SucceededCount)
+	require.Equal(t, int32(1), batch.FailedCount)
+	require.True(t, batch.CompletedAt.Valid)
+
+	items, err := testQueries.ListBatchItems(context.Background(), ListBatchItemsParams{
+		BatchID: batch.ID,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, BatchItemStatusFailed, items[0].Status)
+	require.Equal(t, "insufficient funds", items[0].FailureReason)
+}
+
+func TestRecordBatchItemTx(t *testing.T) {
+	store := NewStore(testDB)
+	result := createTestBatch(t, store, createTestAccount(t), createTestAccount(t), createTestAccount(t))
+
+	failed := UpdateBatchItemStatusParams{
+		ID:            result.Items[0].ID,
+		BatchID:       result.Batch.ID,
+		Status:        BatchItemStatusFailed,
+		FailureReason: "the account does not have enough funds",
+	}
+	recorded, err := store.RecordBatchItemTx(context.Background(), failed)
+	require.NoError(t, err)
+	require.True(t, recorded.Recorded)
+	require.Equal(t, BatchStatusProcessing, recorded.Batch.Status)
+	require.Zero(t, recorded.Batch.SucceededCount)
+	require.Equal(t, int32(1), recorded.Batch.FailedCount)
+
+	recorded, err = store.RecordBatchItemTx(context.Background(), UpdateBatchItemStatusParams{
+		ID:      result.Items[1].ID,
+		BatchID: result.Batch.ID,
+		Status:  BatchItemStatusSucceeded,
+	})
+	require.NoError(t, err)
+	require.True(t, recorded.Recorded)
+	require.Equal(t, int32(1), recorded.Batch.SucceededCount)
+	require.Equal(t, int32(1), recorded.Batch.FailedCount)
+
+	// recording an item again, as a retried task does, counts it once
+	recorded, err = store.RecordBatchItemTx(context.Background(), failed)
+	require.NoError(t, err)
+	require.False(t, recorded.Recorded)
+	require.Equal(t, int32(1), recorded.Batch.SucceededCount)
+	require.Equal(t, int32(1), recorded.Batch.FailedCount)
+
+	items, err := testQueries.ListBatchItems(context.Background(), ListBatchItemsParams{
+		BatchID: result.Batch.ID,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, BatchItemStatusFailed, items[0].Status)
+	require.Equal(t, BatchItemStatusSucceeded, items[1].Status)
+}
@@ -0,0 +1,26 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dibrito/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCurrencies(t *testing.T) {
+	currencies, err := testQueries.ListCurrencies(context.Background())
+	require.NoError(t, err)
+
+	codes := make(map[string]Currency, len(currencies))
+	for _, c := range currencies {
+		codes[c.Code] = c
+	}
+	// every currency used by the random test data is seeded
+	for _, code := range []string{util.EUR, util.USD, util.BRL} {
+		c, ok := codes[code]
+		require.True(t, ok, code)
+		require.True(t, c.Enabled)
+		require.Equal(t, int32(2), c.MinorUnit)
+	}
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -49,15 +50,50 @@ func TestListEntry(t *testing.T) {
 		createTestEntry(t, acc.ID)
 	}
 
-	entries, err := testQueries.ListEntries(context.Background(), ListEntriesParams{
+	page1, err := testQueries.ListEntries(context.Background(), ListEntriesParams{
 		AccountID: acc.ID,
-		Limit:     5,
-		Offset:    5,
+		PageSize:  5,
 	})
 	require.NoError(t, err)
-	require.Len(t, entries, 5)
+	require.Len(t, page1, 5)
+
+	last := page1[len(page1)-1]
+	page2, err := testQueries.ListEntries(context.Background(), ListEntriesParams{
+		AccountID:       acc.ID,
+		CursorCreatedAt: sql.NullTime{Time: last.CreatedAt, Valid: true},
+		CursorID:        sql.NullInt64{Int64: last.ID, Valid: true},
+		PageSize:        5,
+	})
+	require.NoError(t, err)
+	require.Len(t, page2, 5)
 
-	for _, v := range entries {
-		require.NotEmpty(t, v)
+	// newest first, and the second page starts strictly after the cursor
+	entries := append(page1, page2...)
+	for i := 1; i < len(entries); i++ {
+		require.Greater(t, entries[i-1].ID, entries[i].ID)
+	}
+}
+
+func TestListEntryFilters(t *testing.T) {
+	acc := createTestAccount(t)
+	for i := 0; i < 5; i++ {
+		createTestEntry(t, acc.ID)
 	}
+
+	entries, err := testQueries.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: acc.ID,
+		Direction: sql.NullString{String: "debit", Valid: true},
+		PageSize:  5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	entries, err = testQueries.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: acc.ID,
+		Direction: sql.NullString{String: "credit", Valid: true},
+		StartTime: sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
 }
@@ -0,0 +1,13 @@
+package db
+
+// Kinds of fee a schedule charges.
+const (
+	FeeKindFlat       = "flat"
+	FeeKindPercentage = "percentage"
+)
+
+// Transfer types a fee schedule applies to.
+const (
+	TransferTypeSameCurrency  = "same_currency"
+	TransferTypeCrossCurrency = "cross_currency"
+)
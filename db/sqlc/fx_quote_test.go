@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestFxQuote(t *testing.T, from, to Account, expiresAt time.Time) FxQuote {
+	arg := CreateFxQuoteParams{
+		ID:            uuid.New(),
+		Username:      from.Owner.String,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		FromCurrency:  from.Currency,
+		ToCurrency:    to.Currency,
+		Amount:        10,
+		ToAmount:      49,
+		Rate:          "4.9",
+		Spread:        "0.01",
+		ExpiresAt:     expiresAt,
+	}
+	quote, err := testQueries.CreateFxQuote(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, quote.ID)
+	require.Equal(t, arg.Username, quote.Username)
+	require.Equal(t, arg.Amount, quote.Amount)
+	require.Equal(t, arg.ToAmount, quote.ToAmount)
+	require.Equal(t, arg.Rate, quote.Rate)
+	require.False(t, quote.IsUsed)
+	require.WithinDuration(t, arg.ExpiresAt, quote.ExpiresAt, time.Second)
+	return quote
+}
+
+func TestCreateFxQuote(t *testing.T) {
+	createTestFxQuote(t, createTestAccount(t), createTestAccount(t), time.Now().Add(time.Minute))
+}
+
+func TestTransferTxWithQuote(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 100)
+	account2 := createTestAccount(t)
+	quote := createTestFxQuote(t, account1, account2, time.Now().Add(time.Minute))
+
+	arg := TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        quote.Amount,
+		Username:      account1.Owner.String,
+		QuoteID:       quote.ID,
+	}
+	result, err := store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, quote.ToAmount, result.Transfer.ToAmount)
+	require.Equal(t, quote.Rate, result.Transfer.ExchangeRate)
+	require.Equal(t, quote.ToAmount, result.ToEntry.Amount)
+
+	// a quote can only be used once
+	_, err = store.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrQuoteUnavailable)
+}
+
+func TestTransferTxWithInvalidQuote(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 100)
+	account2 := createTestAccount(t)
+
+	expired := createTestFxQuote(t, account1, account2, time.Now().Add(-time.Minute))
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        expired.Amount,
+		Username:      account1.Owner.String,
+		QuoteID:       expired.ID,
+	})
+	require.ErrorIs(t, err, ErrQuoteUnavailable)
+
+	// the quote is bound to the amount it was issued for
+	quote := createTestFxQuote(t, account1, account2, time.Now().Add(time.Minute))
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        quote.Amount + 1,
+		Username:      account1.Owner.String,
+		QuoteID:       quote.ID,
+	})
+	require.ErrorIs(t, err, ErrQuoteUnavailable)
+
+	updated, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updated.Balance)
+}
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestHold(t *testing.T, store Store, from, to Account, amount int64, expiresAt time.Time) Hold {
+	result, err := store.HoldTx(context.Background(), HoldTxParams{
+		AccountID:   from.ID,
+		ToAccountID: to.ID,
+		Amount:      amount,
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+
+	hold := result.Hold
+	require.NotZero(t, hold.ID)
+	require.Equal(t, from.ID, hold.AccountID)
+	require.Equal(t, to.ID, hold.ToAccountID)
+	require.Equal(t, amount, hold.Amount)
+	require.Equal(t, HoldStatusPending, hold.Status)
+	require.False(t, hold.TransferID.Valid)
+	require.WithinDuration(t, expiresAt, hold.ExpiresAt, time.Second)
+
+	// the ledger balance is untouched, only the available balance goes down
+	require.Equal(t, from.Balance, result.Account.Balance)
+	require.Equal(t, from.HeldAmount+amount, result.Account.HeldAmount)
+	return hold
+}
+
+func TestHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createTestAccount(t)
+	account2 := createTestAccount(t)
+
+	createTestHold(t, store, account1, account2, account1.Balance, time.Now().Add(time.Minute))
+
+	// held funds can neither be held again nor transferred
+	_, err := store.HoldTx(context.Background(), HoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      1,
+		ExpiresAt:   time.Now().Add(time.Minute),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createTestAccount(t)
+	account2 := createTestAccount(t)
+
+	amount := int64(10)
+	hold := createTestHold(t, store, account1, account2, amount, time.Now().Add(time.Minute))
+
+	// a partial capture releases the remainder
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Amount: 6,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusCaptured, result.Hold.Status)
+	require.Equal(t, result.Transfer.ID, result.Hold.TransferID.Int64)
+	require.Equal(t, int64(6), result.Transfer.Amount)
+	require.Equal(t, account1.Balance-6, result.FromAccount.Balance)
+	require.Equal(t, account1.HeldAmount, result.FromAccount.HeldAmount)
+	require.Equal(t, account2.Balance+6, result.ToAccount.Balance)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotPending)
+
+	account1 = result.FromAccount
+	hold = createTestHold(t, store, account1, account2, amount, time.Now().Add(time.Minute))
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Amount: amount + 1,
+	})
+	require.ErrorIs(t, err, ErrCaptureExceedsHold)
+
+	// the hold above is still pending
+	account1.HeldAmount += amount
+	expired := createTestHold(t, store, account1, account2, amount, time.Now().Add(-time.Minute))
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: expired.ID})
+	require.ErrorIs(t, err, ErrHoldExpired)
+}
+
+func TestCaptureHoldTxFee(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createTestAccount(t)
+	account2 := createTestAccount(t)
+	revenue := getTestSystemAccount(t, SystemAccountFeeRevenue, account1.Currency)
+
+	hold := createTestHold(t, store, account1, account2, 10, time.Now().Add(time.Minute))
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Fee:    2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Transfer.Fee)
+	require.Equal(t, int64(-12), result.FromEntry.Amount)
+	require.Equal(t, account1.Balance-12, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+10, result.ToAccount.Balance)
+
+	// the fee is credited to the fee revenue account
+	revenue2, err := testQueries.GetAccount(context.Background(), revenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, revenue.Balance+2, revenue2.Balance)
+}
+
+func TestVoidHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createTestAccount(t)
+	account2 := createTestAccount(t)
+
+	hold := createTestHold(t, store, account1, account2, 10, time.Now().Add(-time.Minute))
+
+	expired, err := store.ListExpiredHolds(context.Background(), 1000)
+	require.NoError(t, err)
+	require.Contains(t, expired, hold)
+
+	result, err := store.VoidHoldTx(context.Background(), VoidHoldTxParams{HoldID: hold.ID})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusVoided, result.Hold.Status)
+	require.Equal(t, account1.Balance, result.Account.Balance)
+	require.Equal(t, account1.HeldAmount, result.Account.HeldAmount)
+
+	_, err = store.VoidHoldTx(context.Background(), VoidHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotPending)
+}
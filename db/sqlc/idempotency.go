@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is replayed
+// with a request that differs from the one it was first used with.
+var ErrIdempotencyKeyReused = errors.New("idempotency key already used with a different request")
+
+// requestHash returns a stable hash of a request, used to tell a genuine
+// retry apart from a different request reusing the same idempotency key.
+func requestHash(req interface{}) (string, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("marshal request:%w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// claimIdempotencyKey reserves the key for this transaction. If the key was
+// already used by a committed transaction, the stored response is decoded into
+// result and replayed is true.
+// Concurrent requests with the same key block on the primary key until the
+// first one commits or rolls back, so only one of them can run the operation.
+func claimIdempotencyKey(ctx context.Context, q *Queries, username, key, hash string, result interface{}) (replayed bool, err error) {
+	_, err = q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Username:    username,
+		Key:         key,
+		RequestHash: hash,
+	})
+	if err == nil {
+		return false, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, err
+	}
+
+	// ON CONFLICT DO NOTHING returns no rows: the key is taken
+	existing, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: username,
+		Key:      key,
+	})
+	if err != nil {
+		return false, err
+	}
+	if existing.RequestHash != hash {
+		return false, ErrIdempotencyKeyReused
+	}
+
+	err = json.Unmarshal(existing.Response, result)
+	if err != nil {
+		return false, fmt.Errorf("unmarshal idempotent response:%w", err)
+	}
+	return true, nil
+}
+
+// saveIdempotentResponse stores the result to be returned on replays.
+func saveIdempotentResponse(ctx context.Context, q *Queries, username, key string, result interface{}) error {
+	response, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal idempotent response:%w", err)
+	}
+
+	return q.UpdateIdempotencyKeyResponse(ctx, UpdateIdempotencyKeyResponseParams{
+		Response: response,
+		Username: username,
+		Key:      key,
+	})
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestInterestAccrual(t *testing.T, account Account, day time.Time, amount int64) InterestAccrual {
+	arg := CreateInterestAccrualParams{
+		AccountID:   account.ID,
+		AccrualDate: day,
+		Balance:     account.Balance,
+		AnnualRate:  "0.02",
+		Amount:      amount,
+	}
+	accrual, err := testQueries.CreateInterestAccrual(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.AccountID, accrual.AccountID)
+	require.True(t, arg.AccrualDate.Equal(accrual.AccrualDate))
+	require.Equal(t, arg.Amount, accrual.Amount)
+	require.False(t, accrual.PostedAt.Valid)
+	return accrual
+}
+
+func TestGetInterestRate(t *testing.T) {
+	rate, err := testQueries.GetInterestRate(context.Background(), GetInterestRateParams{
+		AccountType:   AccountTypeSavings,
+		Currency:      "USD",
+		EffectiveFrom: time.Now(),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, rate.AnnualRate)
+
+	// checking accounts earn nothing
+	_, err = testQueries.GetInterestRate(context.Background(), GetInterestRateParams{
+		AccountType:   AccountTypeChecking,
+		Currency:      "USD",
+		EffectiveFrom: time.Now(),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestInterestAccrualPosting(t *testing.T) {
+	account := createTestSavingsAccount(t, createTestAccount(t))
+	monthStart := time.Date(2030, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	createTestInterestAccrual(t, account, monthStart.AddDate(0, 0, -2), 3)
+	createTestInterestAccrual(t, account, monthStart.AddDate(0, 0, -1), 4)
+	// accrued this month, paid next month
+	createTestInterestAccrual(t, account, monthStart, 5)
+
+	_, err := testQueries.CreateInterestAccrual(context.Background(), CreateInterestAccrualParams{
+		AccountID:   account.ID,
+		AccrualDate: monthStart,
+		AnnualRate:  "0.02",
+	})
+	require.Error(t, err, "an account accrues once a day")
+
+	arg := ListUnpostedInterestParams{
+		Before:         monthStart,
+		AfterAccountID: account.ID - 1,
+		PageSize:       1,
+	}
+	rows, err := testQueries.ListUnpostedInterest(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, account.ID, rows[0].AccountID)
+	require.Equal(t, account.Currency, rows[0].Currency)
+	require.Equal(t, int64(7), rows[0].Amount)
+	require.True(t, monthStart.AddDate(0, 0, -2).Equal(rows[0].FirstAccrualDate))
+
+	err = testQueries.MarkInterestAccrualsPosted(context.Background(), MarkInterestAccrualsPostedParams{
+		AccountID: account.ID,
+		Before:    monthStart,
+	})
+	require.NoError(t, err)
+
+	rows, err = testQueries.ListUnpostedInterest(context.Background(), arg)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NotEqual(t, account.ID, row.AccountID)
+	}
+
+	accruals, err := testQueries.ListInterestAccruals(context.Background(), ListInterestAccrualsParams{
+		AccountID: account.ID,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, accruals, 3)
+	require.False(t, accruals[0].PostedAt.Valid)
+	require.True(t, accruals[1].PostedAt.Valid)
+	require.True(t, accruals[2].PostedAt.Valid)
+}
@@ -5,17 +5,76 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
+	ID int64 `json:"id"`
+	// username, null for system accounts
+	Owner     sql.NullString `json:"owner"`
+	Balance   int64          `json:"balance"`
+	Currency  string         `json:"currency"`
+	CreatedAt time.Time      `json:"created_at"`
+	// how far below zero the balance may go
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// sum of pending holds, not available to spend
+	HeldAmount int64 `json:"held_amount"`
+	// balance the account was created with, before any entry
+	OpeningBalance int64 `json:"opening_balance"`
+	// active, frozen or closed. frozen accounts only receive money, closed accounts do nothing
+	Status string `json:"status"`
+	// checking, savings or system
+	Type     string `json:"type"`
+	Nickname string `json:"nickname"`
+	// set when the account is closed
+	ClosedAt sql.NullTime `json:"closed_at"`
+	// user who froze the account, null for a freeze by the bank. only they can lift it
+	FrozenBy sql.NullString `json:"frozen_by"`
+}
+
+type Batch struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	Currency      string `json:"currency"`
+	// pending, processing or completed
+	Status    string `json:"status"`
+	ItemCount int32  `json:"item_count"`
+	// sum of the items amount
+	TotalAmount    int64        `json:"total_amount"`
+	SucceededCount int32        `json:"succeeded_count"`
+	FailedCount    int32        `json:"failed_count"`
+	CreatedAt      time.Time    `json:"created_at"`
+	CompletedAt    sql.NullTime `json:"completed_at"`
+}
+
+type BatchItem struct {
+	ID      int64 `json:"id"`
+	BatchID int64 `json:"batch_id"`
+	// position of the item in the request, from 1
+	RowNumber   int32 `json:"row_number"`
+	ToAccountID int64 `json:"to_account_id"`
+	// must be positive
+	Amount int64 `json:"amount"`
+	// pending, succeeded or failed
+	Status        string        `json:"status"`
+	FailureReason string        `json:"failure_reason"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+}
+
+type Currency struct {
+	// ISO 4217 alphabetic code
+	Code string `json:"code"`
+	// ISO 4217 numeric code
+	NumericCode int32 `json:"numeric_code"`
+	// number of decimal places amounts are stored in
+	MinorUnit int32 `json:"minor_unit"`
+	// whether new accounts and transfers may use it
+	Enabled bool `json:"enabled"`
 }
 
 type Entry struct {
@@ -24,6 +83,130 @@ type Entry struct {
 	// can be negative
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// transfer that posted this entry
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+type FeeSchedule struct {
+	ID       int64  `json:"id"`
+	Currency string `json:"currency"`
+	// same_currency or cross_currency
+	TransferType string `json:"transfer_type"`
+	// flat charges flat_amount, percentage charges percentage of the amount within min_amount and max_amount
+	Kind       string `json:"kind"`
+	FlatAmount int64  `json:"flat_amount"`
+	// fraction of the amount, e.g. 0.01 for 1%
+	Percentage string `json:"percentage"`
+	MinAmount  int64  `json:"min_amount"`
+	// no cap when null
+	MaxAmount sql.NullInt64 `json:"max_amount"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type FxQuote struct {
+	ID            uuid.UUID `json:"id"`
+	Username      string    `json:"username"`
+	FromAccountID int64     `json:"from_account_id"`
+	ToAccountID   int64     `json:"to_account_id"`
+	FromCurrency  string    `json:"from_currency"`
+	ToCurrency    string    `json:"to_currency"`
+	// amount debited, in from_currency
+	Amount int64 `json:"amount"`
+	// amount credited, in to_currency
+	ToAmount int64 `json:"to_amount"`
+	// rate offered to the user, spread included
+	Rate string `json:"rate"`
+	// fraction taken off the market rate
+	Spread    string    `json:"spread"`
+	IsUsed    bool      `json:"is_used"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type Hold struct {
+	ID          int64 `json:"id"`
+	AccountID   int64 `json:"account_id"`
+	ToAccountID int64 `json:"to_account_id"`
+	// amount reserved on account_id
+	Amount int64 `json:"amount"`
+	// pending, captured or voided
+	Status string `json:"status"`
+	// transfer that settled the hold once captured
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+}
+
+type IdempotencyKey struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+	// sha256 of the request the key was first used with
+	RequestHash string `json:"request_hash"`
+	// serialized result returned on replay
+	Response  json.RawMessage `json:"response"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	// balance at the end of accrual_date
+	Balance    int64  `json:"balance"`
+	AnnualRate string `json:"annual_rate"`
+	// interest earned on accrual_date, in minor units
+	Amount int64 `json:"amount"`
+	// monthly credit that paid this accrual
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	// set once the accrual is paid, or settled at zero
+	PostedAt  sql.NullTime `json:"posted_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type InterestRate struct {
+	ID          int64  `json:"id"`
+	AccountType string `json:"account_type"`
+	Currency    string `json:"currency"`
+	// fraction of the balance paid per year, e.g. 0.02 for 2%
+	AnnualRate string `json:"annual_rate"`
+	// first day the rate applies, until a later rate takes over
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Payee struct {
+	ID       int64  `json:"id"`
+	Owner    string `json:"owner"`
+	Nickname string `json:"nickname"`
+	// account paid, set when username is null
+	AccountID sql.NullInt64 `json:"account_id"`
+	// user paid into their account in currency, set when account_id is null
+	Username sql.NullString `json:"username"`
+	// currency credited to the payee
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReconciliationReport struct {
+	ID               int64 `json:"id"`
+	AccountsChecked  int64 `json:"accounts_checked"`
+	TransfersChecked int64 `json:"transfers_checked"`
+	DiscrepancyCount int32 `json:"discrepancy_count"`
+	// every inconsistency found, empty when the ledger balances
+	Discrepancies json.RawMessage `json:"discrepancies"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type SecurityEvent struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// refresh_token_reused
+	Kind string `json:"kind"`
+	// session the event was detected on
+	SessionID uuid.NullUUID `json:"session_id"`
+	UserAgent string        `json:"user_agent"`
+	ClientIp  string        `json:"client_ip"`
+	CreatedAt time.Time     `json:"created_at"`
 }
 
 type Session struct {
@@ -35,6 +218,48 @@ type Session struct {
 	IsBlocked    bool      `json:"is_blocked"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	CreatedAt    time.Time `json:"created_at"`
+	// id of the login session the refresh token was rotated from
+	FamilyID uuid.UUID `json:"family_id"`
+	// session whose refresh token was exchanged for this one
+	ParentID uuid.NullUUID `json:"parent_id"`
+	// set when the refresh token is exchanged, presenting it again blocks the family
+	ConsumedAt sql.NullTime `json:"consumed_at"`
+}
+
+type StandingOrder struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	// cron spec such as 0 9 1 * * or a descriptor such as @monthly or @every 168h
+	Schedule  string    `json:"schedule"`
+	NextRunAt time.Time `json:"next_run_at"`
+	// no run is scheduled after it, runs forever when null
+	EndAt sql.NullTime `json:"end_at"`
+	// active, paused, completed or cancelled
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type StandingOrderExecution struct {
+	ID              int64         `json:"id"`
+	StandingOrderID int64         `json:"standing_order_id"`
+	TransferID      sql.NullInt64 `json:"transfer_id"`
+	// succeeded or failed
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason"`
+	// time the run was scheduled for
+	RunAt     time.Time `json:"run_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SystemAccount struct {
+	// cash, fee_revenue, interest_expense, suspense or fx_position
+	Purpose   string `json:"purpose"`
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"account_id"`
 }
 
 type Transfer struct {
@@ -44,6 +269,38 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// amount credited, in the destination account currency
+	ToAmount int64 `json:"to_amount"`
+	// rate applied to amount to get to_amount
+	ExchangeRate string `json:"exchange_rate"`
+	// transfer this one refunds
+	ReversalOfID sql.NullInt64 `json:"reversal_of_id"`
+	// part of to_amount refunded so far
+	ReversedAmount int64 `json:"reversed_amount"`
+	// memo shown to both parties
+	Description string `json:"description"`
+	// identifier in the client system, e.g. an invoice number
+	ExternalReference string `json:"external_reference"`
+	// labels to filter the transfer history on
+	Tags []string `json:"tags"`
+	// charged to the sender on top of amount and credited to the bank fee revenue account
+	Fee int64 `json:"fee"`
+}
+
+type TransferLimit struct {
+	ID int64 `json:"id"`
+	// null for the bank default in currency
+	Username sql.NullString `json:"username"`
+	// null for the limits on all accounts of username in currency
+	AccountID       sql.NullInt64 `json:"account_id"`
+	Currency        string        `json:"currency"`
+	MaxSingleAmount int64         `json:"max_single_amount"`
+	// outgoing sum allowed per UTC day
+	MaxDailyAmount int64 `json:"max_daily_amount"`
+	// outgoing transfers allowed per UTC day
+	MaxDailyCount int32     `json:"max_daily_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type User struct {
@@ -54,6 +311,8 @@ type User struct {
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 	IsEmailVerified   bool      `json:"is_email_verified"`
+	// customer, support, admin or auditor. it decides which RPCs the user may call
+	Role string `json:"role"`
 }
 
 type VerifyEmail struct {
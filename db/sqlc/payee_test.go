@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dibrito/simple-bank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPayee(t *testing.T, owner User, account Account) Payee {
+	arg := CreatePayeeParams{
+		Owner:     owner.Username,
+		Nickname:  util.RandomOwner(),
+		AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+		Currency:  account.Currency,
+	}
+
+	payee, err := testQueries.CreatePayee(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, payee.ID)
+	require.Equal(t, arg.Owner, payee.Owner)
+	require.Equal(t, arg.Nickname, payee.Nickname)
+	require.Equal(t, arg.AccountID, payee.AccountID)
+	require.False(t, payee.Username.Valid)
+	require.Equal(t, arg.Currency, payee.Currency)
+	require.NotZero(t, payee.CreatedAt)
+	return payee
+}
+
+func TestCreatePayee(t *testing.T) {
+	owner := createRandomUser(t)
+	payee := createTestPayee(t, owner, createTestAccount(t))
+
+	// nicknames are unique per owner
+	_, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     owner.Username,
+		Nickname:  payee.Nickname,
+		AccountID: payee.AccountID,
+		Currency:  payee.Currency,
+	})
+	require.Error(t, err)
+	require.Equal(t, "unique_violation", err.(*pq.Error).Code.Name())
+
+	// exactly one of account_id or username
+	_, err = testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     owner.Username,
+		Nickname:  util.RandomOwner(),
+		AccountID: payee.AccountID,
+		Username:  sql.NullString{String: owner.Username, Valid: true},
+		Currency:  payee.Currency,
+	})
+	require.Error(t, err)
+	require.Equal(t, "check_violation", err.(*pq.Error).Code.Name())
+}
+
+func TestGetNewestPayeeOfAccount(t *testing.T) {
+	owner := createRandomUser(t)
+	account := createTestAccount(t)
+
+	arg := GetNewestPayeeOfAccountParams{
+		Owner:        owner.Username,
+		AccountID:    sql.NullInt64{Int64: account.ID, Valid: true},
+		AccountOwner: account.Owner.String,
+		Currency:     account.Currency,
+	}
+	_, err := testQueries.GetNewestPayeeOfAccount(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	createTestPayee(t, owner, account)
+	byUsername, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:    owner.Username,
+		Nickname: util.RandomOwner(),
+		Username: account.Owner,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	payee, err := testQueries.GetNewestPayeeOfAccount(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, byUsername.ID, payee.ID)
+}
+
+func TestUpdatePayeeNickname(t *testing.T) {
+	payee := createTestPayee(t, createRandomUser(t), createTestAccount(t))
+	nickname := util.RandomOwner()
+
+	updated, err := testQueries.UpdatePayeeNickname(context.Background(), UpdatePayeeNicknameParams{
+		ID:       payee.ID,
+		Nickname: nickname,
+	})
+	require.NoError(t, err)
+	require.Equal(t, nickname, updated.Nickname)
+	require.Equal(t, payee.AccountID, updated.AccountID)
+}
+
+func TestDeletePayee(t *testing.T) {
+	payee := createTestPayee(t, createRandomUser(t), createTestAccount(t))
+
+	err := testQueries.DeletePayee(context.Background(), payee.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetPayee(context.Background(), payee.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListPayees(t *testing.T) {
+	owner := createRandomUser(t)
+	for i := 0; i < 3; i++ {
+		createTestPayee(t, owner, createTestAccount(t))
+	}
+
+	payees, err := testQueries.ListPayees(context.Background(), ListPayeesParams{
+		Owner:  owner.Username,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, payees, 3)
+	for i, payee := range payees {
+		require.Equal(t, owner.Username, payee.Owner)
+		if i > 0 {
+			require.LessOrEqual(t, payees[i-1].Nickname, payee.Nickname)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileTx(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 100)
+	account2 := createTestAccount(t)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// a balance edited by hand no longer matches its entries
+	_, err = testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		Amount: 5,
+		ID:     account2.ID,
+	})
+	require.NoError(t, err)
+
+	report, err := store.ReconcileTx(context.Background())
+	require.NoError(t, err)
+	require.NotZero(t, report.ID)
+	require.NotZero(t, report.AccountsChecked)
+	require.NotZero(t, report.TransfersChecked)
+
+	var discrepancies []Discrepancy
+	require.NoError(t, json.Unmarshal(report.Discrepancies, &discrepancies))
+	require.Len(t, discrepancies, int(report.DiscrepancyCount))
+	require.Contains(t, discrepancies, Discrepancy{
+		Kind:      DiscrepancyBalance,
+		AccountID: account2.ID,
+		Expected:  account2.Balance + 10,
+		Actual:    account2.Balance + 15,
+	})
+	for _, d := range discrepancies {
+		require.NotEqual(t, account1.ID, d.AccountID)
+	}
+
+	latest, err := store.GetLatestReconciliationReport(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, report.ID, latest.ID)
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dibrito/simple-bank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSession(t *testing.T, user User, expiresAt time.Time) Session {
+	id := uuid.New()
+	arg := CreateSessionParams{
+		ID:           id,
+		Username:     user.Username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    "test",
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    expiresAt,
+		FamilyID:     id,
+	}
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.ID)
+	require.Equal(t, arg.ID, session.FamilyID)
+	require.False(t, session.IsBlocked)
+	require.False(t, session.ConsumedAt.Valid)
+	return session
+}
+
+func nextTestSession(session Session) CreateSessionParams {
+	return CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     session.Username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    "test",
+		ClientIp:     "127.0.0.2",
+		ExpiresAt:    session.ExpiresAt,
+	}
+}
+
+func TestListSessionsByUser(t *testing.T) {
+	user := createRandomUser(t)
+	older := createTestSession(t, user, time.Now().Add(time.Hour))
+	newer := createTestSession(t, user, time.Now().Add(time.Hour))
+	blocked := createTestSession(t, user, time.Now().Add(time.Hour))
+	err := testQueries.BlockSessionFamily(context.Background(), blocked.FamilyID)
+	require.NoError(t, err)
+	consumed := createTestSession(t, user, time.Now().Add(time.Hour))
+	_, err = testQueries.ConsumeSession(context.Background(), consumed.ID)
+	require.NoError(t, err)
+	createTestSession(t, user, time.Now().Add(-time.Minute))
+
+	// blocked, consumed and expired sessions are left out
+	sessions, err := testQueries.ListSessionsByUser(context.Background(), ListSessionsByUserParams{
+		Username: user.Username,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, newer.ID, sessions[0].ID)
+	require.Equal(t, older.ID, sessions[1].ID)
+}
+
+func TestBlockSession(t *testing.T) {
+	user := createRandomUser(t)
+	session := createTestSession(t, user, time.Now().Add(time.Hour))
+	arg := nextTestSession(session)
+	arg.FamilyID = session.FamilyID
+	next, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+
+	blocked, err := testQueries.BlockSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.Equal(t, session.ID, blocked.ID)
+	require.True(t, blocked.IsBlocked)
+
+	// the rest of the family is left alone
+	next, err = testQueries.GetSession(context.Background(), next.ID)
+	require.NoError(t, err)
+	require.False(t, next.IsBlocked)
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	store := NewStore(testDB)
+	session := createTestSession(t, createRandomUser(t), time.Now().Add(time.Hour))
+
+	next, err := store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		Session:    session,
+		NewSession: nextTestSession(session),
+	})
+	require.NoError(t, err)
+	require.Equal(t, session.FamilyID, next.FamilyID)
+	require.Equal(t, session.ID, next.ParentID.UUID)
+
+	consumed, err := store.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, consumed.ConsumedAt.Valid)
+
+	// the next token rotates in turn, the chain stays one family
+	last, err := store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		Session:    next,
+		NewSession: nextTestSession(next),
+	})
+	require.NoError(t, err)
+	require.Equal(t, session.FamilyID, last.FamilyID)
+	require.Equal(t, next.ID, last.ParentID.UUID)
+}
+
+func TestRotateSessionTxReuse(t *testing.T) {
+	store := NewStore(testDB)
+	session := createTestSession(t, createRandomUser(t), time.Now().Add(time.Hour))
+
+	next, err := store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		Session:    session,
+		NewSession: nextTestSession(session),
+	})
+	require.NoError(t, err)
+
+	// presenting the first token again blocks the token issued for it
+	reuse := nextTestSession(session)
+	_, err = store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		Session:    session,
+		NewSession: reuse,
+	})
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	for _, id := range []uuid.UUID{session.ID, next.ID} {
+		s, err := store.GetSession(context.Background(), id)
+		require.NoError(t, err)
+		require.True(t, s.IsBlocked)
+	}
+	_, err = store.GetSession(context.Background(), reuse.ID)
+	require.Error(t, err)
+
+	events, err := store.ListSecurityEvents(context.Background(), ListSecurityEventsParams{
+		Username: session.Username,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, SecurityEventRefreshTokenReused, events[0].Kind)
+	require.Equal(t, session.ID, events[0].SessionID.UUID)
+	require.Equal(t, reuse.ClientIp, events[0].ClientIp)
+}
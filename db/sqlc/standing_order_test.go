@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStandingOrder(t *testing.T, from, to Account, nextRunAt time.Time) StandingOrder {
+	arg := CreateStandingOrderParams{
+		Owner:         from.Owner.String,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        10,
+		Currency:      from.Currency,
+		Schedule:      "@monthly",
+		NextRunAt:     nextRunAt,
+	}
+
+	order, err := testQueries.CreateStandingOrder(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, order.ID)
+	require.Equal(t, arg.Owner, order.Owner)
+	require.Equal(t, arg.FromAccountID, order.FromAccountID)
+	require.Equal(t, arg.ToAccountID, order.ToAccountID)
+	require.Equal(t, arg.Amount, order.Amount)
+	require.Equal(t, arg.Schedule, order.Schedule)
+	require.WithinDuration(t, nextRunAt, order.NextRunAt, time.Second)
+	require.False(t, order.EndAt.Valid)
+	require.Equal(t, StandingOrderStatusActive, order.Status)
+	return order
+}
+
+func TestUpdateStandingOrder(t *testing.T) {
+	order := createTestStandingOrder(t, createTestAccount(t), createTestAccount(t), time.Now().Add(time.Hour))
+
+	paused, err := testQueries.UpdateStandingOrder(context.Background(), UpdateStandingOrderParams{
+		ID:     order.ID,
+		Status: sql.NullString{String: StandingOrderStatusPaused, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderStatusPaused, paused.Status)
+	// fields left null are untouched
+	require.Equal(t, order.Amount, paused.Amount)
+	require.Equal(t, order.Schedule, paused.Schedule)
+	require.WithinDuration(t, order.NextRunAt, paused.NextRunAt, time.Second)
+
+	got, err := testQueries.GetStandingOrder(context.Background(), order.ID)
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderStatusPaused, got.Status)
+}
+
+func TestListDueStandingOrders(t *testing.T) {
+	from := createTestAccount(t)
+	to := createTestAccount(t)
+	due := createTestStandingOrder(t, from, to, time.Now().Add(-time.Minute))
+	notDue := createTestStandingOrder(t, from, to, time.Now().Add(time.Hour))
+	paused := createTestStandingOrder(t, from, to, time.Now().Add(-time.Minute))
+	_, err := testQueries.UpdateStandingOrder(context.Background(), UpdateStandingOrderParams{
+		ID:     paused.ID,
+		Status: sql.NullString{String: StandingOrderStatusPaused, Valid: true},
+	})
+	require.NoError(t, err)
+
+	orders, err := testQueries.ListDueStandingOrders(context.Background(), 1000)
+	require.NoError(t, err)
+
+	ids := make(map[int64]bool)
+	for _, order := range orders {
+		require.Equal(t, StandingOrderStatusActive, order.Status)
+		require.False(t, order.NextRunAt.After(time.Now()))
+		ids[order.ID] = true
+	}
+	require.True(t, ids[due.ID])
+	require.False(t, ids[notDue.ID])
+	require.False(t, ids[paused.ID])
+}
+
+func TestStandingOrderExecutions(t *testing.T) {
+	order := createTestStandingOrder(t, createTestAccount(t), createTestAccount(t), time.Now())
+
+	failed, err := testQueries.CreateStandingOrderExecution(context.Background(), CreateStandingOrderExecutionParams{
+		StandingOrderID: order.ID,
+		Status:          ExecutionStatusFailed,
+		FailureReason:   ErrInsufficientFunds.Error(),
+		RunAt:           order.NextRunAt,
+	})
+	require.NoError(t, err)
+	require.False(t, failed.TransferID.Valid)
+
+	// a run is recorded once
+	_, err = testQueries.CreateStandingOrderExecution(context.Background(), CreateStandingOrderExecutionParams{
+		StandingOrderID: order.ID,
+		Status:          ExecutionStatusSucceeded,
+		RunAt:           order.NextRunAt,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	succeeded, err := testQueries.CreateStandingOrderExecution(context.Background(), CreateStandingOrderExecutionParams{
+		StandingOrderID: order.ID,
+		Status:          ExecutionStatusSucceeded,
+		RunAt:           order.NextRunAt.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Empty(t, succeeded.FailureReason)
+
+	executions, err := testQueries.ListStandingOrderExecutions(context.Background(), ListStandingOrderExecutionsParams{
+		StandingOrderID: order.ID,
+		Limit:           10,
+	})
+	require.NoError(t, err)
+	require.Len(t, executions, 2)
+	// newest first
+	require.Equal(t, succeeded.ID, executions[0].ID)
+	require.Equal(t, failed.ID, executions[1].ID)
+}
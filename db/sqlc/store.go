@@ -12,6 +12,17 @@ type Store interface {
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	HoldTx(ctx context.Context, arg HoldTxParams) (HoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	VoidHoldTx(ctx context.Context, arg VoidHoldTxParams) (VoidHoldTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	ReconcileTx(ctx context.Context) (ReconciliationReport, error)
+	CreateBatchTx(ctx context.Context, arg CreateBatchTxParams) (CreateBatchTxResult, error)
+	RecordBatchItemTx(ctx context.Context, arg UpdateBatchItemStatusParams) (RecordBatchItemTxResult, error)
+	CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error)
+	DepositTx(ctx context.Context, arg DepositTxParams) (TransferTxResult, error)
+	WithdrawTx(ctx context.Context, arg WithdrawTxParams) (TransferTxResult, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error)
 	Querier
 }
 
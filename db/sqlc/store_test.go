@@ -5,9 +5,22 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/dibrito/simple-bank/util"
 	"github.com/stretchr/testify/require"
 )
 
+// setTestOverdraftLimit lets an account go negative so a test that is not
+// about funds cannot fail because of a small random balance.
+func setTestOverdraftLimit(t *testing.T, account Account, limit int64) Account {
+	account, err := testQueries.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             account.ID,
+		OverdraftLimit: limit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, limit, account.OverdraftLimit)
+	return account
+}
+
 func TestTransferTx(t *testing.T) {
 	store := NewStore(testDB)
 	account1 := createTestAccount(t)
@@ -16,6 +29,7 @@ func TestTransferTx(t *testing.T) {
 	// run n concurrent transfer transactions
 	n := 1
 	amount := int64(10)
+	account1 = setTestOverdraftLimit(t, account1, int64(n)*amount)
 
 	errs := make(chan error)
 	results := make(chan TransferTxResult)
@@ -61,6 +75,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotEmpty(t, fromEntry)
 		require.Equal(t, account1.ID, fromEntry.AccountID)
 		require.Equal(t, -amount, fromEntry.Amount)
+		require.Equal(t, transfer.ID, fromEntry.TransferID.Int64)
 		require.NotZero(t, fromEntry.ID)
 		require.NotZero(t, fromEntry.CreatedAt)
 
@@ -71,6 +86,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotEmpty(t, toEntry)
 		require.Equal(t, account2.ID, toEntry.AccountID)
 		require.Equal(t, amount, toEntry.Amount)
+		require.Equal(t, transfer.ID, toEntry.TransferID.Int64)
 		require.NotZero(t, toEntry.ID)
 		require.NotZero(t, toEntry.CreatedAt)
 
@@ -117,6 +133,8 @@ func TestTransferTxDeadLock(t *testing.T) {
 	// run n concurrent transfer transactions
 	n := 10
 	amount := int64(10)
+	account1 = setTestOverdraftLimit(t, account1, int64(n)*amount)
+	account2 = setTestOverdraftLimit(t, account2, int64(n)*amount)
 
 	errs := make(chan error)
 
@@ -155,3 +173,340 @@ func TestTransferTxDeadLock(t *testing.T) {
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 	fmt.Println(">> after:", account1.Balance, account2.Balance)
 }
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createTestAccount(t)
+	account2 := createTestAccount(t)
+
+	// run n concurrent transfers whose total is more than account1 can afford
+	n := 10
+	amount := account1.Balance/4 + 1
+	expectedSuccess := int(account1.Balance / amount)
+
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			})
+			errs <- err
+		}()
+	}
+
+	success := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err != nil {
+			require.ErrorIs(t, err, ErrInsufficientFunds)
+			continue
+		}
+		success++
+	}
+	require.Equal(t, expectedSuccess, success)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, account1.Balance-int64(success)*amount, updatedAccount1.Balance)
+	require.Equal(t, account2.Balance+int64(success)*amount, updatedAccount2.Balance)
+	require.GreaterOrEqual(t, updatedAccount1.Balance, int64(0))
+}
+
+func TestTransferTxOverdraftLimit(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createTestAccount(t)
+	account2 := createTestAccount(t)
+
+	limit := util.RandomInt(1, 1000)
+	account1 = setTestOverdraftLimit(t, account1, limit)
+
+	// transfers in both directions so account1 is locked by debits and credits
+	n := 20
+	amount := (account1.Balance+limit)/5 + 1
+
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		fromAccountID := account1.ID
+		toAccountID := account2.ID
+		if i%4 == 3 {
+			fromAccountID = account2.ID
+			toAccountID = account1.ID
+		}
+
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: fromAccountID,
+				ToAccountID:   toAccountID,
+				Amount:        amount,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err != nil {
+			require.ErrorIs(t, err, ErrInsufficientFunds)
+		}
+	}
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, updatedAccount1.Balance, -limit)
+	require.GreaterOrEqual(t, updatedAccount2.Balance, int64(0))
+	// money is only moved, never created
+	require.Equal(t, account1.Balance+account2.Balance, updatedAccount1.Balance+updatedAccount2.Balance)
+}
+
+func TestTransferTxIdempotency(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createTestAccount(t)
+	account2 := createTestAccount(t)
+	account1 = setTestOverdraftLimit(t, account1, 100)
+
+	arg := TransferTxParams{
+		FromAccountID:  account1.ID,
+		ToAccountID:    account2.ID,
+		Amount:         10,
+		Username:       account1.Owner.String,
+		IdempotencyKey: util.RandomString(32),
+	}
+
+	// concurrent retries of the same request only move money once
+	n := 5
+	errs := make(chan error)
+	results := make(chan TransferTxResult)
+	for i := 0; i < n; i++ {
+		go func() {
+			result, err := store.TransferTx(context.Background(), arg)
+			errs <- err
+			results <- result
+		}()
+	}
+
+	var transferID int64
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+
+		result := <-results
+		require.NotZero(t, result.Transfer.ID)
+		if transferID == 0 {
+			transferID = result.Transfer.ID
+		}
+		require.Equal(t, transferID, result.Transfer.ID)
+		require.Equal(t, arg.Amount, result.Transfer.Amount)
+		require.Equal(t, account1.ID, result.FromAccount.ID)
+		require.Equal(t, account2.ID, result.ToAccount.ID)
+	}
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-arg.Amount, updatedAccount1.Balance)
+	require.Equal(t, account2.Balance+arg.Amount, updatedAccount2.Balance)
+
+	// a retry is replayed even if the server worked out another fee or rate
+	retry := arg
+	retry.Fee = 1
+	retry.ExchangeRate = "1.1"
+	result, err := store.TransferTx(context.Background(), retry)
+	require.NoError(t, err)
+	require.Equal(t, transferID, result.Transfer.ID)
+
+	// same key with a different body is rejected
+	arg.Amount = 20
+	_, err = store.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	// so is one with another memo
+	arg.Amount = 10
+	arg.Description = util.RandomString(8)
+	_, err = store.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	// the key is scoped by user
+	arg.Description = ""
+	arg.Username = account2.Owner.String
+	result, err = store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEqual(t, transferID, result.Transfer.ID)
+}
+
+func TestTransferTxCrossCurrency(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createTestAccount(t)
+	account2 := createTestAccount(t)
+
+	amount := int64(10)
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		ToAmount:      50,
+		ExchangeRate:  "5",
+	})
+	require.NoError(t, err)
+
+	// each leg is recorded in its own account currency
+	require.Equal(t, -amount, result.FromEntry.Amount)
+	require.Equal(t, int64(50), result.ToEntry.Amount)
+	require.Equal(t, int64(50), result.Transfer.ToAmount)
+	require.Equal(t, "5", result.Transfer.ExchangeRate)
+
+	require.Equal(t, account1.Balance-amount, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+50, result.ToAccount.Balance)
+}
+
+func TestTransferTxFee(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 100)
+	account2 := createTestAccount(t)
+
+	// the fee goes to the revenue account in the sender currency
+	revenueID, err := testQueries.GetSystemAccountID(context.Background(), GetSystemAccountIDParams{
+		Purpose:  SystemAccountFeeRevenue,
+		Currency: account1.Currency,
+	})
+	require.NoError(t, err)
+	revenue, err := testQueries.GetAccount(context.Background(), revenueID)
+	require.NoError(t, err)
+
+	amount := int64(10)
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		Fee:           3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.Fee)
+	require.Equal(t, int64(3), result.Transfer.Fee)
+
+	// the sender pays the fee on top, the recipient gets the amount
+	require.Equal(t, -amount-3, result.FromEntry.Amount)
+	require.Equal(t, amount, result.ToEntry.Amount)
+	require.Equal(t, account1.Balance-amount-3, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+amount, result.ToAccount.Balance)
+
+	// the fee is the third entry of the transfer
+	entries, err := testQueries.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: revenueID,
+		PageSize:  1,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, int64(3), entries[0].Amount)
+	require.Equal(t, result.Transfer.ID, entries[0].TransferID.Int64)
+
+	revenue2, err := testQueries.GetAccount(context.Background(), revenueID)
+	require.NoError(t, err)
+	require.Equal(t, revenue.Balance+3, revenue2.Balance)
+
+	// a fee entry matching the transfer fee keeps it balanced
+	unbalanced, err := testQueries.ListUnbalancedTransfers(context.Background())
+	require.NoError(t, err)
+	for _, u := range unbalanced {
+		require.NotEqual(t, result.Transfer.ID, u.ID)
+	}
+}
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 100)
+	account2 := createTestAccount(t)
+
+	amount := int64(10)
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	})
+	require.NoError(t, err)
+
+	// a partial refund
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), result.Original.ReversedAmount)
+	require.Equal(t, transfer.Transfer.ID, result.Transfer.ReversalOfID.Int64)
+	require.Equal(t, account2.ID, result.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, result.Transfer.ToAccountID)
+	require.Equal(t, int64(4), result.Transfer.Amount)
+	require.Equal(t, int64(-4), result.FromEntry.Amount)
+	require.Equal(t, int64(4), result.ToEntry.Amount)
+
+	// no more than what is left
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     amount,
+	})
+	require.ErrorIs(t, err, ErrReversalExceedsTransfer)
+
+	// the rest
+	result2, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, amount, result2.Original.ReversedAmount)
+	require.Equal(t, int64(6), result2.Transfer.Amount)
+
+	// then nothing
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrReversalExceedsTransfer)
+
+	// a reversal is final
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferIsReversal)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
+
+func TestReverseTransferTxCrossCurrency(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 100)
+	account2 := createTestAccount(t)
+
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		ToAmount:      33,
+		ExchangeRate:  "3.3",
+	})
+	require.NoError(t, err)
+
+	// partial refunds round down but always add up to the original amount
+	var refunded int64
+	for _, amount := range []int64{10, 10, 13} {
+		result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+			TransferID: transfer.Transfer.ID,
+			Amount:     amount,
+		})
+		require.NoError(t, err)
+		require.Equal(t, amount, result.Transfer.Amount)
+		require.Equal(t, "0.303030303", result.Transfer.ExchangeRate)
+		refunded += result.Transfer.ToAmount
+	}
+	require.Equal(t, int64(10), refunded)
+}
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dibrito/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestSystemAccount(t *testing.T, purpose, currency string) Account {
+	id, err := testQueries.GetSystemAccountID(context.Background(), GetSystemAccountIDParams{
+		Purpose:  purpose,
+		Currency: currency,
+	})
+	require.NoError(t, err)
+	account, err := testQueries.GetAccount(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeSystem, account.Type)
+	require.False(t, account.Owner.Valid)
+	return account
+}
+
+func trialBalance(t *testing.T) map[string]int64 {
+	rows, err := testQueries.ListTrialBalance(context.Background())
+	require.NoError(t, err)
+	totals := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		totals[row.Currency] = row.Total
+	}
+	return totals
+}
+
+func TestDepositTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createTestAccount(t)
+	cash := getTestSystemAccount(t, SystemAccountCash, account.Currency)
+
+	result, err := store.DepositTx(context.Background(), DepositTxParams{
+		AccountID:   account.ID,
+		Amount:      25,
+		Description: "branch deposit",
+	})
+	require.NoError(t, err)
+	require.Equal(t, cash.ID, result.Transfer.FromAccountID)
+	require.Equal(t, account.ID, result.Transfer.ToAccountID)
+	require.Equal(t, []string{"deposit"}, result.Transfer.Tags)
+
+	// the cash account gives what the customer account receives
+	require.Equal(t, int64(-25), result.FromEntry.Amount)
+	require.Equal(t, int64(25), result.ToEntry.Amount)
+	require.Equal(t, account.Balance+25, result.ToAccount.Balance)
+	require.Equal(t, cash.Balance-25, result.FromAccount.Balance)
+
+	_, err = store.DepositTx(context.Background(), DepositTxParams{
+		AccountID: cash.ID,
+		Amount:    25,
+	})
+	require.ErrorIs(t, err, ErrSystemAccount)
+}
+
+func TestWithdrawTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createTestAccount(t)
+	cash := getTestSystemAccount(t, SystemAccountCash, account.Currency)
+
+	result, err := store.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, result.Transfer.FromAccountID)
+	require.Equal(t, cash.ID, result.Transfer.ToAccountID)
+	require.Equal(t, account.Balance-10, result.FromAccount.Balance)
+	require.Equal(t, cash.Balance+10, result.ToAccount.Balance)
+
+	// a withdrawal is limited like a transfer
+	createTestTransferLimit(t, account, true, 5, 1000, 10)
+	_, err = store.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    6,
+	})
+	require.ErrorIs(t, err, ErrSingleTransferLimit)
+}
+
+func TestTrialBalance(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	from, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Currency: util.USD,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+	to, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Currency: util.EUR,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+	fxUSD := getTestSystemAccount(t, SystemAccountFXPosition, util.USD)
+	fxEUR := getTestSystemAccount(t, SystemAccountFXPosition, util.EUR)
+	before := trialBalance(t)
+
+	_, err = store.DepositTx(context.Background(), DepositTxParams{
+		AccountID: from.ID,
+		Amount:    100,
+	})
+	require.NoError(t, err)
+
+	// a cross-currency transfer with a fee is balanced in both currencies
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        50,
+		ToAmount:      45,
+		ExchangeRate:  "0.9",
+		Fee:           2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(48), result.FromAccount.Balance)
+	require.Equal(t, int64(45), result.ToAccount.Balance)
+
+	fxUSD2, err := testQueries.GetAccount(context.Background(), fxUSD.ID)
+	require.NoError(t, err)
+	require.Equal(t, fxUSD.Balance+50, fxUSD2.Balance)
+	fxEUR2, err := testQueries.GetAccount(context.Background(), fxEUR.ID)
+	require.NoError(t, err)
+	require.Equal(t, fxEUR.Balance-45, fxEUR2.Balance)
+
+	unbalanced, err := testQueries.ListUnbalancedTransfers(context.Background())
+	require.NoError(t, err)
+	for _, u := range unbalanced {
+		require.NotEqual(t, result.Transfer.ID, u.ID)
+	}
+
+	after := trialBalance(t)
+	require.Equal(t, before[util.USD], after[util.USD])
+	require.Equal(t, before[util.EUR], after[util.EUR])
+}
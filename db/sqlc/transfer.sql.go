@@ -7,26 +7,97 @@ package db
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
 )
 
+const addTransferReversedAmount = `-- name: AddTransferReversedAmount :one
+UPDATE transfers SET reversed_amount = reversed_amount + $1
+WHERE id = $2
+RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversal_of_id, reversed_amount, description, external_reference, tags, fee
+`
+
+type AddTransferReversedAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddTransferReversedAmount(ctx context.Context, arg AddTransferReversedAmountParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, addTransferReversedAmount, arg.Amount, arg.ID)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversalOfID,
+		&i.ReversedAmount,
+		&i.Description,
+		&i.ExternalReference,
+		pq.Array(&i.Tags),
+		&i.Fee,
+	)
+	return i, err
+}
+
+const countTransfers = `-- name: CountTransfers :one
+SELECT COUNT(*) FROM transfers
+`
+
+func (q *Queries) CountTransfers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTransfers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  to_amount,
+  exchange_rate,
+  reversal_of_id,
+  description,
+  external_reference,
+  tags,
+  fee
 ) VALUES (
-  $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversal_of_id, reversed_amount, description, external_reference, tags, fee
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID     int64         `json:"from_account_id"`
+	ToAccountID       int64         `json:"to_account_id"`
+	Amount            int64         `json:"amount"`
+	ToAmount          int64         `json:"to_amount"`
+	ExchangeRate      string        `json:"exchange_rate"`
+	ReversalOfID      sql.NullInt64 `json:"reversal_of_id"`
+	Description       string        `json:"description"`
+	ExternalReference string        `json:"external_reference"`
+	Tags              []string      `json:"tags"`
+	Fee               int64         `json:"fee"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+		arg.ReversalOfID,
+		arg.Description,
+		arg.ExternalReference,
+		pq.Array(arg.Tags),
+		arg.Fee,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -34,12 +105,20 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversalOfID,
+		&i.ReversedAmount,
+		&i.Description,
+		&i.ExternalReference,
+		pq.Array(&i.Tags),
+		&i.Fee,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversal_of_id, reversed_amount, description, external_reference, tags, fee FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -52,33 +131,95 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversalOfID,
+		&i.ReversedAmount,
+		&i.Description,
+		&i.ExternalReference,
+		pq.Array(&i.Tags),
+		&i.Fee,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversal_of_id, reversed_amount, description, external_reference, tags, fee FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversalOfID,
+		&i.ReversedAmount,
+		&i.Description,
+		&i.ExternalReference,
+		pq.Array(&i.Tags),
+		&i.Fee,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
-WHERE 
-    from_account_id = $1 OR
-    to_account_id = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversal_of_id, reversed_amount, description, external_reference, tags, fee FROM transfers
+WHERE (from_account_id = $1 OR to_account_id = $1)
+  AND ($2::timestamptz IS NULL OR created_at >= $2)
+  AND ($3::timestamptz IS NULL OR created_at < $3)
+  AND ($4::text IS NULL
+    OR ($4 = 'debit' AND from_account_id = $1)
+    OR ($4 = 'credit' AND to_account_id = $1))
+  AND ($5::bigint IS NULL OR amount >= $5)
+  AND ($6::bigint IS NULL OR amount <= $6)
+  AND ($7::bigint IS NULL
+    OR from_account_id = $7 OR to_account_id = $7)
+  AND ($8::varchar IS NULL OR tags @> ARRAY[$8::varchar])
+  AND ($9::text IS NULL
+    OR to_tsvector('simple', description) @@ plainto_tsquery('simple', $9))
+  AND ($10::timestamptz IS NULL
+    OR (created_at, id) < ($10, $11::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT $12
 `
 
 type ListTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+	AccountID       int64          `json:"account_id"`
+	StartTime       sql.NullTime   `json:"start_time"`
+	EndTime         sql.NullTime   `json:"end_time"`
+	Direction       sql.NullString `json:"direction"`
+	MinAmount       sql.NullInt64  `json:"min_amount"`
+	MaxAmount       sql.NullInt64  `json:"max_amount"`
+	CounterpartyID  sql.NullInt64  `json:"counterparty_id"`
+	Tag             sql.NullString `json:"tag"`
+	Search          sql.NullString `json:"search"`
+	CursorCreatedAt sql.NullTime   `json:"cursor_created_at"`
+	CursorID        sql.NullInt64  `json:"cursor_id"`
+	PageSize        int32          `json:"page_size"`
 }
 
 func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
 	rows, err := q.db.QueryContext(ctx, listTransfers,
-		arg.FromAccountID,
-		arg.ToAccountID,
-		arg.Limit,
-		arg.Offset,
+		arg.AccountID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Direction,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CounterpartyID,
+		arg.Tag,
+		arg.Search,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
 	)
 	if err != nil {
 		return nil, err
@@ -93,6 +234,14 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ToAmount,
+			&i.ExchangeRate,
+			&i.ReversalOfID,
+			&i.ReversedAmount,
+			&i.Description,
+			&i.ExternalReference,
+			pq.Array(&i.Tags),
+			&i.Fee,
 		); err != nil {
 			return nil, err
 		}
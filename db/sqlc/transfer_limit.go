@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSingleTransferLimit is returned when a transfer is above the largest
+// amount allowed in one transfer.
+var ErrSingleTransferLimit = errors.New("transfer is above the single transfer limit")
+
+// ErrDailyTransferLimit is returned when a transfer would go over the sum or
+// count of transfers allowed per day.
+var ErrDailyTransferLimit = errors.New("daily transfer limit reached")
+
+// EffectiveTransferLimit combines rules into the strictest of each limit.
+// It returns false if there are no rules.
+func EffectiveTransferLimit(rules []TransferLimit) (TransferLimit, bool) {
+	if len(rules) == 0 {
+		return TransferLimit{}, false
+	}
+	limit := rules[0]
+	for _, rule := range rules[1:] {
+		if rule.MaxSingleAmount < limit.MaxSingleAmount {
+			limit.MaxSingleAmount = rule.MaxSingleAmount
+		}
+		if rule.MaxDailyAmount < limit.MaxDailyAmount {
+			limit.MaxDailyAmount = rule.MaxDailyAmount
+		}
+		if rule.MaxDailyCount < limit.MaxDailyCount {
+			limit.MaxDailyCount = rule.MaxDailyCount
+		}
+	}
+	return limit, true
+}
+
+// StartOfDay returns the start of the UTC day of t, when daily limits reset.
+func StartOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// checkTransferLimits checks a transfer of amount from account, already
+// recorded, against the rules on the account and on its owner.
+// The account row must be locked by the caller. The owner row is locked here,
+// last, so transfers from different accounts of a user are checked in turn.
+func checkTransferLimits(ctx context.Context, q *Queries, account Account, amount int64) error {
+	if account.Type == AccountTypeSystem {
+		return nil
+	}
+
+	rules, err := q.ListTransferLimits(ctx, ListTransferLimitsParams{
+		Currency:  account.Currency,
+		Username:  account.Owner.String,
+		AccountID: account.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	_, err = q.GetUserForUpdate(ctx, account.Owner.String)
+	if err != nil {
+		return err
+	}
+
+	usage, err := q.GetDailyTransferUsage(ctx, GetDailyTransferUsageParams{
+		AccountID: account.ID,
+		Owner:     account.Owner.String,
+		Currency:  account.Currency,
+		Since:     StartOfDay(time.Now()),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if amount > rule.MaxSingleAmount {
+			return fmt.Errorf("%w of %d %s", ErrSingleTransferLimit, rule.MaxSingleAmount, rule.Currency)
+		}
+		// an account rule counts the account transfers, the others all of the owner's
+		sum, count := usage.UserAmount, usage.UserCount
+		if rule.AccountID.Valid {
+			sum, count = usage.AccountAmount, usage.AccountCount
+		}
+		if sum > rule.MaxDailyAmount {
+			return fmt.Errorf("%w, at most %d %s per day", ErrDailyTransferLimit, rule.MaxDailyAmount, rule.Currency)
+		}
+		if count > int64(rule.MaxDailyCount) {
+			return fmt.Errorf("%w, at most %d transfers per day", ErrDailyTransferLimit, rule.MaxDailyCount)
+		}
+	}
+	return nil
+}
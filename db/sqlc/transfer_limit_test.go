@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestTransferLimit(t *testing.T, account Account, accountRule bool, single, daily int64, count int32) TransferLimit {
+	arg := CreateTransferLimitParams{
+		Username:        sql.NullString{String: account.Owner.String, Valid: true},
+		AccountID:       sql.NullInt64{Int64: account.ID, Valid: accountRule},
+		Currency:        account.Currency,
+		MaxSingleAmount: single,
+		MaxDailyAmount:  daily,
+		MaxDailyCount:   count,
+	}
+	limit, err := testQueries.CreateTransferLimit(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, limit.Username)
+	require.Equal(t, arg.AccountID, limit.AccountID)
+	require.Equal(t, single, limit.MaxSingleAmount)
+	return limit
+}
+
+func TestTransferTxAccountLimits(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 1000)
+	account2 := createTestAccount(t)
+	createTestTransferLimit(t, account1, true, 50, 80, 2)
+
+	transfer := func(amount int64) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		return err
+	}
+
+	require.ErrorIs(t, transfer(60), ErrSingleTransferLimit)
+	require.NoError(t, transfer(40))
+	require.ErrorIs(t, transfer(50), ErrDailyTransferLimit)
+	require.NoError(t, transfer(40))
+	require.ErrorIs(t, transfer(1), ErrDailyTransferLimit)
+
+	// rejected transfers are rolled back and not counted
+	usage, err := testQueries.GetDailyTransferUsage(context.Background(), GetDailyTransferUsageParams{
+		AccountID: account1.ID,
+		Owner:     account1.Owner.String,
+		Currency:  account1.Currency,
+		Since:     StartOfDay(time.Now()),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(80), usage.AccountAmount)
+	require.Equal(t, int64(2), usage.AccountCount)
+
+	got, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-80, got.Balance)
+}
+
+func TestTransferTxUserLimits(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 1000)
+	account2 := setTestOverdraftLimit(t, createTestSavingsAccount(t, account1), 1000)
+	account3 := createTestAccount(t)
+	createTestTransferLimit(t, account1, false, 100, 100, 10)
+
+	// the user rule covers the sum of all accounts of the user in the currency
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account3.ID,
+		Amount:        70,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account3.ID,
+		Amount:        40,
+	})
+	require.ErrorIs(t, err, ErrDailyTransferLimit)
+
+	rules, err := testQueries.ListTransferLimits(context.Background(), ListTransferLimitsParams{
+		Currency:  account1.Currency,
+		Username:  account1.Owner.String,
+		AccountID: account2.ID,
+	})
+	require.NoError(t, err)
+	limit, ok := EffectiveTransferLimit(rules)
+	require.True(t, ok)
+	require.Equal(t, int64(100), limit.MaxDailyAmount)
+}
+
+func TestCaptureHoldTxLimits(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := setTestOverdraftLimit(t, createTestAccount(t), 1000)
+	account2 := createTestAccount(t)
+	createTestTransferLimit(t, account1, true, 50, 80, 2)
+
+	// a hold reserves the money but only the capture moves it
+	hold := createTestHold(t, store, account1, account2, 60, time.Now().Add(time.Minute))
+	_, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrSingleTransferLimit)
+
+	// the rejected capture is rolled back and the hold stays pending
+	got, err := testQueries.GetHold(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusPending, got.Status)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID, Amount: 50})
+	require.NoError(t, err)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	hold = createTestHold(t, store, account1, account2, 40, time.Now().Add(time.Minute))
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrDailyTransferLimit)
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -10,10 +11,14 @@ import (
 )
 
 func createTestTransfer(t *testing.T, from, to int64) Transfer {
+	amount := util.RandonMoney()
 	arg := CreateTransferParams{
 		FromAccountID: from,
 		ToAccountID:   to,
-		Amount:        util.RandonMoney(),
+		Amount:        amount,
+		ToAmount:      amount,
+		ExchangeRate:  "1",
+		Tags:          []string{},
 	}
 	transfer, err := testQueries.CreateTransfer(context.Background(), arg)
 	require.NoError(t, err)
@@ -21,6 +26,8 @@ func createTestTransfer(t *testing.T, from, to int64) Transfer {
 	require.Equal(t, arg.FromAccountID, transfer.FromAccountID)
 	require.Equal(t, arg.ToAccountID, transfer.ToAccountID)
 	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.ToAmount, transfer.ToAmount)
+	require.Equal(t, arg.ExchangeRate, transfer.ExchangeRate)
 
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
@@ -57,16 +64,113 @@ func TestListTransfer(t *testing.T) {
 		createTestTransfer(t, from.ID, to.ID)
 	}
 
+	page1, err := testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		AccountID: from.ID,
+		PageSize:  5,
+	})
+	require.NoError(t, err)
+	require.Len(t, page1, 5)
+
+	last := page1[len(page1)-1]
+	page2, err := testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		AccountID:       from.ID,
+		CursorCreatedAt: sql.NullTime{Time: last.CreatedAt, Valid: true},
+		CursorID:        sql.NullInt64{Int64: last.ID, Valid: true},
+		PageSize:        5,
+	})
+	require.NoError(t, err)
+	require.Len(t, page2, 5)
+
+	transfers := append(page1, page2...)
+	for i := 1; i < len(transfers); i++ {
+		require.Greater(t, transfers[i-1].ID, transfers[i].ID)
+	}
+}
+
+func TestListTransferFilters(t *testing.T) {
+	from := createTestAccount(t)
+	to := createTestAccount(t)
+	other := createTestAccount(t)
+	for i := 0; i < 3; i++ {
+		createTestTransfer(t, from.ID, to.ID)
+		createTestTransfer(t, other.ID, from.ID)
+	}
+
 	transfers, err := testQueries.ListTransfers(context.Background(), ListTransfersParams{
-		FromAccountID: from.ID,
-		ToAccountID:   to.ID,
-		Limit:         5,
-		Offset:        5,
+		AccountID: from.ID,
+		Direction: sql.NullString{String: "debit", Valid: true},
+		PageSize:  10,
 	})
 	require.NoError(t, err)
-	require.Len(t, transfers, 5)
+	require.Len(t, transfers, 3)
+	for _, v := range transfers {
+		require.Equal(t, from.ID, v.FromAccountID)
+	}
 
+	transfers, err = testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		AccountID:      from.ID,
+		CounterpartyID: sql.NullInt64{Int64: other.ID, Valid: true},
+		PageSize:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 3)
 	for _, v := range transfers {
-		require.NotEmpty(t, v)
+		require.Equal(t, other.ID, v.FromAccountID)
+	}
+}
+
+func TestListTransferTagAndSearch(t *testing.T) {
+	store := NewStore(testDB)
+	from := createTestAccount(t)
+	to := createTestAccount(t)
+
+	arg := TransferTxParams{
+		FromAccountID:     from.ID,
+		ToAccountID:       to.ID,
+		Amount:            10,
+		Description:       "Rent for the flat in March",
+		ExternalReference: "INV-" + util.RandomString(6),
+		Tags:              []string{"rent", "home"},
 	}
+	rent, err := store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Description, rent.Transfer.Description)
+	require.Equal(t, arg.ExternalReference, rent.Transfer.ExternalReference)
+	require.Equal(t, arg.Tags, rent.Transfer.Tags)
+
+	// transfers without details get an empty tag list
+	plain, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, plain.Transfer.Tags)
+
+	transfers, err := testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		AccountID: from.ID,
+		Tag:       sql.NullString{String: "home", Valid: true},
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, rent.Transfer.ID, transfers[0].ID)
+
+	// every word must match, in any case and order
+	transfers, err = testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		AccountID: from.ID,
+		Search:    sql.NullString{String: "march RENT", Valid: true},
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, rent.Transfer.ID, transfers[0].ID)
+
+	transfers, err = testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		AccountID: from.ID,
+		Search:    sql.NullString{String: "rent april", Valid: true},
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, transfers)
 }
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrSystemAccount is returned when a deposit or withdrawal targets a system
+// account instead of a customer account.
+var ErrSystemAccount = errors.New("system accounts cannot deposit or withdraw cash")
+
+// DepositTxParams contains the input parameters of the deposit transaction
+type DepositTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+	// Description is a free text memo, searchable in the transfer history.
+	Description string `json:"description"`
+}
+
+// DepositTx credits cash to a customer account. The money comes from the
+// cash system account of the account currency, so it is recorded as a
+// transfer with balanced entries like any other movement.
+// It fails with ErrAccountClosed if the account is closed.
+func (store *SQLStore) DepositTx(ctx context.Context, arg DepositTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, cashID, err := cashAccounts(ctx, q, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		result, err = postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: cashID,
+			ToAccountID:   account.ID,
+			Amount:        arg.Amount,
+			ToAmount:      arg.Amount,
+			ExchangeRate:  "1",
+			Description:   arg.Description,
+			Tags:          []string{"deposit"},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// WithdrawTxParams contains the input parameters of the withdrawal transaction
+type WithdrawTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+	// Description is a free text memo, searchable in the transfer history.
+	Description string `json:"description"`
+}
+
+// WithdrawTx debits cash from a customer account to the cash system account
+// of its currency.
+// It fails like TransferTx does for the source account: with
+// ErrInsufficientFunds, ErrAccountFrozen, ErrAccountClosed,
+// ErrSingleTransferLimit or ErrDailyTransferLimit.
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg WithdrawTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, cashID, err := cashAccounts(ctx, q, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		result, err = postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: account.ID,
+			ToAccountID:   cashID,
+			Amount:        arg.Amount,
+			ToAmount:      arg.Amount,
+			ExchangeRate:  "1",
+			Description:   arg.Description,
+			Tags:          []string{"withdrawal"},
+		})
+		if err != nil {
+			return err
+		}
+
+		return checkTransferLimits(ctx, q, result.FromAccount, arg.Amount)
+	})
+
+	return result, err
+}
+
+// cashAccounts returns the customer account accountID and the id of the cash
+// system account in its currency.
+func cashAccounts(ctx context.Context, q *Queries, accountID int64) (Account, int64, error) {
+	account, err := q.GetAccount(ctx, accountID)
+	if err != nil {
+		return account, 0, err
+	}
+	if account.Type == AccountTypeSystem {
+		return account, 0, ErrSystemAccount
+	}
+
+	cashID, err := q.GetSystemAccountID(ctx, GetSystemAccountIDParams{
+		Purpose:  SystemAccountCash,
+		Currency: account.Currency,
+	})
+	if err != nil {
+		return account, 0, fmt.Errorf("get %s cash account:%w", account.Currency, err)
+	}
+	return account, cashID, nil
+}
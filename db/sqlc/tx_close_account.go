@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrAccountNotEmpty is returned when closing an account that still has
+	// money on it, or funds reserved by holds, and nowhere to sweep it.
+	ErrAccountNotEmpty = errors.New("account balance must be zero or swept to another account")
+	// ErrInvalidSweepAccount is returned when the sweep destination is the
+	// account being closed or belongs to another owner or currency.
+	ErrInvalidSweepAccount = errors.New("sweep account must be another open account of the same owner and currency")
+)
+
+// CloseAccountTxParams contains the input parameters of the close transaction
+type CloseAccountTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// SweepToAccountID, when set, receives the remaining balance.
+	SweepToAccountID int64 `json:"sweep_to_account_id"`
+}
+
+// CloseAccountTxResult is the result of the close transaction
+type CloseAccountTxResult struct {
+	Account Account `json:"account"`
+	// Sweep is the transfer of the remaining balance, if there was one.
+	Sweep *TransferTxResult `json:"sweep"`
+}
+
+// CloseAccountTx closes an account. The account is kept, with its entries,
+// but takes no more money movements. A positive balance is swept to
+// SweepToAccountID, otherwise the balance must be zero; it fails with
+// ErrAccountNotEmpty if it is not, or if holds are still pending.
+// Sweeping a frozen account fails with ErrAccountFrozen.
+func (store *SQLStore) CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error) {
+	var result CloseAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// lock the accounts in id order, as postTransfer does, so the
+		// close cannot deadlock with a transfer between the two
+		if arg.SweepToAccountID != 0 && arg.SweepToAccountID < arg.AccountID {
+			if _, err := q.GetAccountForUpdate(ctx, arg.SweepToAccountID); err != nil {
+				return err
+			}
+		}
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.Status == AccountStatusClosed {
+			return ErrAccountClosed
+		}
+		if account.HeldAmount != 0 || account.Balance < 0 {
+			return ErrAccountNotEmpty
+		}
+		if arg.SweepToAccountID != 0 {
+			if err := checkSweepAccount(ctx, q, account, arg.SweepToAccountID); err != nil {
+				return err
+			}
+		}
+
+		if account.Balance > 0 {
+			if arg.SweepToAccountID == 0 {
+				return ErrAccountNotEmpty
+			}
+			// the sweep is a debit, so a frozen account must be
+			// unfrozen before its balance can be swept
+			sweep, err := postTransfer(ctx, q, CreateTransferParams{
+				FromAccountID: account.ID,
+				ToAccountID:   arg.SweepToAccountID,
+				Amount:        account.Balance,
+				ToAmount:      account.Balance,
+				ExchangeRate:  "1",
+				Description:   "account closure",
+			})
+			if err != nil {
+				return err
+			}
+			result.Sweep = &sweep
+		}
+
+		result.Account, err = q.CloseAccount(ctx, account.ID)
+		return err
+	})
+
+	return result, err
+}
+
+func checkSweepAccount(ctx context.Context, q *Queries, account Account, sweepToAccountID int64) error {
+	if sweepToAccountID == account.ID {
+		return ErrInvalidSweepAccount
+	}
+	to, err := q.GetAccount(ctx, sweepToAccountID)
+	if err != nil {
+		return err
+	}
+	if to.Owner != account.Owner || to.Currency != account.Currency || to.Status == AccountStatusClosed {
+		return ErrInvalidSweepAccount
+	}
+	return nil
+}
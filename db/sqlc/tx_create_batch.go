@@ -0,0 +1,104 @@
+package db
+
+import "context"
+
+// BatchItemParams is one leg of a batch, the source account is the batch's.
+type BatchItemParams struct {
+	ToAccountID int64
+	Amount      int64
+}
+
+type CreateBatchTxParams struct {
+	Owner         string
+	FromAccountID int64
+	Currency      string
+	Items         []BatchItemParams
+	// AfterCreate runs inside the transaction, so the batch is only stored
+	// once its processing task was enqueued.
+	AfterCreate func(batch Batch) error
+}
+
+type CreateBatchTxResult struct {
+	Batch Batch
+	Items []BatchItem
+}
+
+// CreateBatchTx stores a batch and its items, numbered from 1 in the given order.
+func (store *SQLStore) CreateBatchTx(ctx context.Context, arg CreateBatchTxParams) (CreateBatchTxResult, error) {
+	var result CreateBatchTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		var total int64
+		for _, item := range arg.Items {
+			total += item.Amount
+		}
+
+		result.Batch, err = q.CreateBatch(ctx, CreateBatchParams{
+			Owner:         arg.Owner,
+			FromAccountID: arg.FromAccountID,
+			Currency:      arg.Currency,
+			ItemCount:     int32(len(arg.Items)),
+			TotalAmount:   total,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]BatchItem, 0, len(arg.Items))
+		for i, item := range arg.Items {
+			batchItem, err := q.CreateBatchItem(ctx, CreateBatchItemParams{
+				BatchID:     result.Batch.ID,
+				RowNumber:   int32(i + 1),
+				ToAccountID: item.ToAccountID,
+				Amount:      item.Amount,
+			})
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, batchItem)
+		}
+
+		return arg.AfterCreate(result.Batch)
+	})
+
+	return result, err
+}
+
+type RecordBatchItemTxResult struct {
+	Batch Batch
+	// Recorded is false if the item was recorded before, the batch
+	// progress is then left as it was.
+	Recorded bool
+}
+
+// RecordBatchItemTx stores the outcome of a pending batch item and counts it
+// in the progress of its batch, so the counts never drift from the items.
+func (store *SQLStore) RecordBatchItemTx(ctx context.Context, arg UpdateBatchItemStatusParams) (RecordBatchItemTxResult, error) {
+	var result RecordBatchItemTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		rows, err := q.UpdateBatchItemStatus(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			result.Batch, err = q.GetBatch(ctx, arg.BatchID)
+			return err
+		}
+		result.Recorded = true
+
+		progress := UpdateBatchProgressParams{
+			ID:     arg.BatchID,
+			Status: BatchStatusProcessing,
+		}
+		if arg.Status == BatchItemStatusSucceeded {
+			progress.Succeeded = 1
+		} else {
+			progress.Failed = 1
+		}
+		result.Batch, err = q.UpdateBatchProgress(ctx, progress)
+		return err
+	})
+
+	return result, err
+}
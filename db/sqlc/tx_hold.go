@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const (
+	HoldStatusPending  = "pending"
+	HoldStatusCaptured = "captured"
+	HoldStatusVoided   = "voided"
+)
+
+var (
+	// ErrHoldNotPending is returned when capturing or voiding a hold that
+	// was already captured or voided.
+	ErrHoldNotPending = errors.New("hold is already captured or voided")
+	// ErrHoldExpired is returned when capturing a hold past its expiry.
+	ErrHoldExpired = errors.New("hold is expired")
+	// ErrCaptureExceedsHold is returned when capturing more than was held.
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds the held amount")
+)
+
+// HoldTxParams contains the input parameters of the hold transaction
+type HoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// HoldTxResult is the result of the hold transaction
+type HoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// HoldTx reserves Amount on an account for a later capture to ToAccountID.
+// The ledger balance is unchanged, only the available balance goes down.
+// It fails with ErrInsufficientFunds if the available balance would go below
+// the overdraft limit, and like a transfer debit on a frozen or closed account.
+func (store *SQLStore) HoldTx(ctx context.Context, arg HoldTxParams) (HoldTxResult, error) {
+	var result HoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			Amount: arg.Amount,
+			ID:     arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := checkAccountStatus(result.Account, -arg.Amount); err != nil {
+			return err
+		}
+		if result.Account.Balance-result.Account.HeldAmount < -result.Account.OverdraftLimit {
+			return ErrInsufficientFunds
+		}
+
+		result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// CaptureHoldTxParams contains the input parameters of the capture transaction
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// Amount settled, at most the held amount. Zero captures the whole hold.
+	Amount int64 `json:"amount"`
+	// Fee is debited from the source account on top of Amount and credited
+	// to the fee revenue system account, as in a transfer.
+	Fee int64 `json:"fee"`
+}
+
+// CaptureHoldTxResult is the result of the capture transaction
+type CaptureHoldTxResult struct {
+	Hold Hold `json:"hold"`
+	TransferTxResult
+}
+
+// CaptureHoldTx settles a pending hold: the reservation is released and a
+// transfer of Amount is posted to the hold destination. Any remainder of a
+// partial capture becomes available again.
+// The transfer counts toward the transfer limits when it is captured, so it
+// fails with ErrSingleTransferLimit or ErrDailyTransferLimit like TransferTx,
+// and the hold stays pending.
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := pendingHold(ctx, q, arg.HoldID)
+		if err != nil {
+			return err
+		}
+		if time.Now().After(hold.ExpiresAt) {
+			return ErrHoldExpired
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = hold.Amount
+		}
+		if amount > hold.Amount {
+			return ErrCaptureExceedsHold
+		}
+
+		// lock the accounts in id order, as postTransfer does, before
+		// releasing the reservation on the source account
+		if hold.ToAccountID < hold.AccountID {
+			if _, err := q.GetAccountForUpdate(ctx, hold.ToAccountID); err != nil {
+				return err
+			}
+		}
+		_, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			Amount: -hold.Amount,
+			ID:     hold.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.TransferTxResult, err = postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        amount,
+			ToAmount:      amount,
+			ExchangeRate:  "1",
+			Fee:           arg.Fee,
+		})
+		if err != nil {
+			return err
+		}
+
+		// the source account row is locked by postTransfer until the commit
+		err = checkTransferLimits(ctx, q, result.FromAccount, amount)
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:         hold.ID,
+			Status:     HoldStatusCaptured,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// VoidHoldTxParams contains the input parameters of the void transaction
+type VoidHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+}
+
+// VoidHoldTxResult is the result of the void transaction
+type VoidHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// VoidHoldTx cancels a pending hold and makes the reserved amount available
+// again. Expired holds can still be voided, which is how they are cleaned up.
+func (store *SQLStore) VoidHoldTx(ctx context.Context, arg VoidHoldTxParams) (VoidHoldTxResult, error) {
+	var result VoidHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := pendingHold(ctx, q, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			Amount: -hold.Amount,
+			ID:     hold.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:     hold.ID,
+			Status: HoldStatusVoided,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// pendingHold locks a hold for the rest of the transaction and checks it can
+// still be captured or voided.
+func pendingHold(ctx context.Context, q *Queries, id int64) (Hold, error) {
+	hold, err := q.GetHoldForUpdate(ctx, id)
+	if err != nil {
+		return hold, err
+	}
+	if hold.Status != HoldStatusPending {
+		return hold, ErrHoldNotPending
+	}
+	return hold, nil
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Kinds of Discrepancy found by ReconcileTx.
+const (
+	// DiscrepancyBalance is an account balance that differs from its opening
+	// balance plus the sum of its entries.
+	DiscrepancyBalance = "balance"
+	// DiscrepancyHeldAmount is an account held amount that differs from the
+	// sum of its pending holds.
+	DiscrepancyHeldAmount = "held_amount"
+	// DiscrepancyEntryCount is a transfer without exactly two entries, plus
+	// one when it charged a fee and two when it crossed currencies.
+	DiscrepancyEntryCount = "entry_count"
+	// DiscrepancyDebit is a transfer whose source entry is not -(amount+fee).
+	DiscrepancyDebit = "debit"
+	// DiscrepancyCredit is a transfer whose destination entry is not to_amount.
+	DiscrepancyCredit = "credit"
+	// DiscrepancyFee is a transfer whose fee entry is not its fee.
+	DiscrepancyFee = "fee"
+	// DiscrepancyTrialBalance is a currency whose entries do not sum to zero.
+	DiscrepancyTrialBalance = "trial_balance"
+)
+
+// Discrepancy is a ledger inconsistency, recorded in a reconciliation report.
+type Discrepancy struct {
+	Kind       string `json:"kind"`
+	AccountID  int64  `json:"account_id,omitempty"`
+	TransferID int64  `json:"transfer_id,omitempty"`
+	Currency   string `json:"currency,omitempty"`
+	Expected   int64  `json:"expected"`
+	Actual     int64  `json:"actual"`
+}
+
+// ReconcileTx checks the ledger is consistent and stores the outcome as a
+// reconciliation report: every account balance must match its opening balance
+// plus its entries, every held amount its pending holds, and every transfer
+// must have one debit of amount plus fee, one credit of to_amount and, with a
+// fee, one credit of the fee. The entries of each currency must sum to zero.
+func (store *SQLStore) ReconcileTx(ctx context.Context) (ReconciliationReport, error) {
+	var report ReconciliationReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		discrepancies := []Discrepancy{}
+
+		balances, err := q.ListAccountBalanceMismatches(ctx)
+		if err != nil {
+			return err
+		}
+		for _, b := range balances {
+			discrepancies = append(discrepancies, Discrepancy{
+				Kind:      DiscrepancyBalance,
+				AccountID: b.ID,
+				Expected:  b.EntriesBalance,
+				Actual:    b.Balance,
+			})
+		}
+
+		held, err := q.ListHeldAmountMismatches(ctx)
+		if err != nil {
+			return err
+		}
+		for _, h := range held {
+			discrepancies = append(discrepancies, Discrepancy{
+				Kind:      DiscrepancyHeldAmount,
+				AccountID: h.ID,
+				Expected:  h.PendingHolds,
+				Actual:    h.HeldAmount,
+			})
+		}
+
+		transfers, err := q.ListUnbalancedTransfers(ctx)
+		if err != nil {
+			return err
+		}
+		for _, t := range transfers {
+			entryCount := int64(2)
+			if t.Fee > 0 {
+				entryCount++
+			}
+			if t.CrossCurrency {
+				entryCount += 2
+			}
+			if t.EntryCount != entryCount {
+				discrepancies = append(discrepancies, Discrepancy{
+					Kind:       DiscrepancyEntryCount,
+					TransferID: t.ID,
+					Expected:   entryCount,
+					Actual:     t.EntryCount,
+				})
+			}
+			if t.Debited != -(t.Amount + t.Fee) {
+				discrepancies = append(discrepancies, Discrepancy{
+					Kind:       DiscrepancyDebit,
+					TransferID: t.ID,
+					Expected:   -(t.Amount + t.Fee),
+					Actual:     t.Debited,
+				})
+			}
+			if t.Credited != t.ToAmount {
+				discrepancies = append(discrepancies, Discrepancy{
+					Kind:       DiscrepancyCredit,
+					TransferID: t.ID,
+					Expected:   t.ToAmount,
+					Actual:     t.Credited,
+				})
+			}
+			if t.FeeCollected != t.Fee {
+				discrepancies = append(discrepancies, Discrepancy{
+					Kind:       DiscrepancyFee,
+					TransferID: t.ID,
+					Expected:   t.Fee,
+					Actual:     t.FeeCollected,
+				})
+			}
+		}
+
+		totals, err := q.ListTrialBalance(ctx)
+		if err != nil {
+			return err
+		}
+		for _, total := range totals {
+			if total.Total != 0 {
+				discrepancies = append(discrepancies, Discrepancy{
+					Kind:     DiscrepancyTrialBalance,
+					Currency: total.Currency,
+					Expected: 0,
+					Actual:   total.Total,
+				})
+			}
+		}
+
+		accounts, err := q.CountAccounts(ctx)
+		if err != nil {
+			return err
+		}
+		transfersChecked, err := q.CountTransfers(ctx)
+		if err != nil {
+			return err
+		}
+
+		raw, err := json.Marshal(discrepancies)
+		if err != nil {
+			return fmt.Errorf("marshal discrepancies:%w", err)
+		}
+
+		report, err = q.CreateReconciliationReport(ctx, CreateReconciliationReportParams{
+			AccountsChecked:  accounts,
+			TransfersChecked: transfersChecked,
+			DiscrepancyCount: int32(len(discrepancies)),
+			Discrepancies:    raw,
+		})
+		return err
+	})
+
+	return report, err
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dibrito/simple-bank/fx"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrTransferIsReversal is returned when reversing a reversal.
+	ErrTransferIsReversal = errors.New("a reversal cannot be reversed")
+	// ErrReversalExceedsTransfer is returned when a refund is larger than
+	// what is left to refund of the original transfer.
+	ErrReversalExceedsTransfer = errors.New("reversal exceeds the amount left to refund")
+)
+
+// ReverseTransferTxParams contains the input parameters of the reversal transaction
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Amount refunded, in the currency the original transfer was credited in.
+	// Zero refunds whatever is left.
+	Amount int64 `json:"amount"`
+}
+
+// ReverseTransferTxResult is the result of the reversal transaction
+type ReverseTransferTxResult struct {
+	// Original is the reversed transfer with its updated ReversedAmount.
+	Original Transfer `json:"original"`
+	TransferTxResult
+}
+
+// ReverseTransferTx refunds all or part of a transfer with a compensating
+// transfer from the recipient back to the sender, linked by reversal_of_id.
+// Refunds add up in the original's reversed_amount, which a check constraint
+// keeps within its to_amount, so a transfer can never be reversed twice.
+// It fails with ErrInsufficientFunds if the recipient cannot cover the refund.
+// The fee of the original transfer is kept, refunds themselves are free.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+		if original.ReversalOfID.Valid {
+			return ErrTransferIsReversal
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = original.ToAmount - original.ReversedAmount
+		}
+		if amount <= 0 || amount > original.ToAmount-original.ReversedAmount {
+			return ErrReversalExceedsTransfer
+		}
+
+		result.Original, err = q.AddTransferReversedAmount(ctx, AddTransferReversedAmountParams{
+			Amount: amount,
+			ID:     original.ID,
+		})
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "check_violation" {
+				return ErrReversalExceedsTransfer
+			}
+			return err
+		}
+
+		// the sender gets back the same share of Amount as the share of ToAmount
+		// refunded; working from the running totals makes partial refunds of a
+		// cross-currency transfer add up to exactly Amount
+		toAmount := refundShare(original.Amount, result.Original.ReversedAmount, original.ToAmount) -
+			refundShare(original.Amount, original.ReversedAmount, original.ToAmount)
+
+		rate, err := inverseRate(original.ExchangeRate)
+		if err != nil {
+			return err
+		}
+
+		result.TransferTxResult, err = postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        amount,
+			ToAmount:      toAmount,
+			ExchangeRate:  rate,
+			ReversalOfID:  sql.NullInt64{Int64: original.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// refundShare returns total*part/whole rounded down, without overflowing.
+func refundShare(total, part, whole int64) int64 {
+	share := new(big.Int).Mul(big.NewInt(total), big.NewInt(part))
+	return share.Quo(share, big.NewInt(whole)).Int64()
+}
+
+// inverseRate returns the rate converting back the currency pair of rate.
+func inverseRate(rate string) (string, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() == 0 {
+		return "", fmt.Errorf("invalid exchange rate %s", rate)
+	}
+	return fx.FormatRate(r.Inv(r)), nil
+}
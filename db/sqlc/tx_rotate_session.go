@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrRefreshTokenReused is returned when a refresh token is presented after
+// it was exchanged. Either it was stolen or the legitimate client lost track
+// of it, so every session of the family is blocked.
+var ErrRefreshTokenReused = errors.New("refresh token was already used, the session is revoked")
+
+// Kinds of SecurityEvent.
+const (
+	// SecurityEventRefreshTokenReused is recorded when a consumed refresh
+	// token is presented again.
+	SecurityEventRefreshTokenReused = "refresh_token_reused"
+)
+
+// RotateSessionTxParams contains the input parameters of the rotation transaction
+type RotateSessionTxParams struct {
+	// Session is the session of the refresh token presented, already checked
+	// to be unblocked, unexpired and of the right user.
+	Session Session
+	// NewSession is the session of the refresh token issued in exchange.
+	// Its family and parent are taken from Session.
+	NewSession CreateSessionParams
+}
+
+// RotateSessionTx exchanges the refresh token of a session for a new one:
+// the session is consumed and NewSession continues its family.
+// If the session was already consumed, the whole family is blocked, a
+// security event is recorded and it fails with ErrRefreshTokenReused.
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error) {
+	var session Session
+	var reused bool
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.ConsumeSession(ctx, arg.Session.ID)
+		if err == sql.ErrNoRows {
+			// the block and the event must be committed, so the
+			// transaction succeeds and the error is returned after it
+			reused = true
+			return revokeSessionFamily(ctx, q, arg.Session, arg.NewSession)
+		}
+		if err != nil {
+			return err
+		}
+
+		arg.NewSession.FamilyID = arg.Session.FamilyID
+		arg.NewSession.ParentID = uuid.NullUUID{UUID: arg.Session.ID, Valid: true}
+		session, err = q.CreateSession(ctx, arg.NewSession)
+		return err
+	})
+	if err != nil {
+		return session, err
+	}
+
+	if reused {
+		log.Warn().Str("username", arg.Session.Username).
+			Str("family_id", arg.Session.FamilyID.String()).
+			Str("client_ip", arg.NewSession.ClientIp).
+			Msg("refresh token reused, session family blocked")
+		return session, ErrRefreshTokenReused
+	}
+	return session, nil
+}
+
+// revokeSessionFamily blocks every session of the family of session and
+// records who presented its consumed refresh token.
+func revokeSessionFamily(ctx context.Context, q *Queries, session Session, presentedBy CreateSessionParams) error {
+	err := q.BlockSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateSecurityEvent(ctx, CreateSecurityEventParams{
+		Username:  session.Username,
+		Kind:      SecurityEventRefreshTokenReused,
+		SessionID: uuid.NullUUID{UUID: session.ID, Valid: true},
+		UserAgent: presentedBy.UserAgent,
+		ClientIp:  presentedBy.ClientIp,
+	})
+	return err
+}
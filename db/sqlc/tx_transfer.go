@@ -1,12 +1,68 @@
 package db
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// ErrInsufficientFunds is returned when a transfer would take the source
+// account below its overdraft limit.
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrQuoteUnavailable is returned when a transfer references an FX quote that
+// is expired, already used, or was issued for a different transfer.
+var ErrQuoteUnavailable = errors.New("quote is expired, already used or does not match the transfer")
 
 // TransferTxParams contains the input parameter of the transfer transactions
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	Amount        int64 `json:"amount"`
+	// Currency is the currency of Amount, as given by the client. The
+	// caller checks it against the source account.
+	Currency string `json:"currency"`
+	// ToAmount is the amount credited to the destination account, in its own
+	// currency. It defaults to Amount for same-currency transfers.
+	ToAmount int64 `json:"to_amount"`
+	// ExchangeRate is the rate applied to Amount to get ToAmount,
+	// as a decimal string. It defaults to "1".
+	ExchangeRate string `json:"exchange_rate"`
+	// QuoteID, when set, locks ToAmount and ExchangeRate to a quote issued
+	// for this transfer. The quote is consumed, so it can only be used once.
+	QuoteID uuid.UUID `json:"quote_id"`
+	// Description is a free text memo, searchable in the transfer history.
+	Description string `json:"description"`
+	// ExternalReference identifies the transfer in the client system.
+	ExternalReference string `json:"external_reference"`
+	// Tags label the transfer for filtering the history.
+	Tags []string `json:"tags"`
+	// Fee is debited from the source account on top of Amount and credited
+	// to the fee revenue system account in the source currency.
+	Fee int64 `json:"fee"`
+	// Username is the user requesting the transfer, the scope of IdempotencyKey.
+	Username string `json:"-"`
+	// IdempotencyKey, when set, makes the transfer safe to retry:
+	// a replay with the same parameters returns the original result.
+	IdempotencyKey string `json:"-"`
+}
+
+// transferRequest is what a client asks for in a transfer, the part of the
+// parameters a retry with the same IdempotencyKey must repeat. The amounts,
+// rate and fee worked out by the server may change between retries.
+type transferRequest struct {
+	FromAccountID     int64     `json:"from_account_id"`
+	ToAccountID       int64     `json:"to_account_id"`
+	Amount            int64     `json:"amount"`
+	Currency          string    `json:"currency"`
+	QuoteID           uuid.UUID `json:"quote_id"`
+	Description       string    `json:"description"`
+	ExternalReference string    `json:"external_reference"`
+	Tags              []string  `json:"tags"`
 }
 
 // TransferTxResult is the result of the transfer transactions
@@ -16,50 +72,103 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account_id"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// Fee charged to the source account, included in FromEntry.
+	Fee int64 `json:"fee"`
 }
 
 // TransferTx performs a money transfer from one account to other.
 // It creates a transfer record, add account entries, and update accounts'balance,
 // within a single database transaction.
+// Each entry is in its account's currency: the source is debited Amount plus
+// Fee and the destination credited ToAmount. A fee is credited to the fee
+// revenue system account, and a cross-currency transfer is balanced in each
+// currency through the FX position system accounts, so the entries of every
+// currency always sum to zero.
+// It fails with ErrInsufficientFunds if the source account would go below
+// its overdraft limit, in which case nothing is persisted.
+// It fails with ErrAccountFrozen if the source account is frozen and
+// ErrAccountClosed if either account is closed.
+// It fails with ErrSingleTransferLimit or ErrDailyTransferLimit if the
+// transfer breaks a limit on the source account or its owner.
+// With a QuoteID, it fails with ErrQuoteUnavailable unless the quote can be consumed.
+// With an IdempotencyKey, a retry returns the stored result of the first
+// successful call and ErrIdempotencyKeyReused if the client parameters differ.
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
-	err := store.execTx(ctx, func(q *Queries) error {
+	if arg.ToAmount == 0 {
+		arg.ToAmount = arg.Amount
+	}
+	if arg.ExchangeRate == "" {
+		arg.ExchangeRate = "1"
+	}
+	var hash string
+	if arg.IdempotencyKey != "" {
 		var err error
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
+		hash, err = requestHash(transferRequest{
+			FromAccountID:     arg.FromAccountID,
+			ToAccountID:       arg.ToAccountID,
+			Amount:            arg.Amount,
+			Currency:          arg.Currency,
+			QuoteID:           arg.QuoteID,
+			Description:       arg.Description,
+			ExternalReference: arg.ExternalReference,
+			Tags:              arg.Tags,
 		})
 		if err != nil {
-			return err
+			return result, err
+		}
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		if arg.IdempotencyKey != "" {
+			replayed, err := claimIdempotencyKey(ctx, q, arg.Username, arg.IdempotencyKey, hash, &result)
+			if err != nil || replayed {
+				return err
+			}
 		}
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
+		if arg.QuoteID != uuid.Nil {
+			quote, err := q.UseFxQuote(ctx, UseFxQuoteParams{
+				ID:            arg.QuoteID,
+				Username:      arg.Username,
+				FromAccountID: arg.FromAccountID,
+				ToAccountID:   arg.ToAccountID,
+				Amount:        arg.Amount,
+			})
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return ErrQuoteUnavailable
+				}
+				return err
+			}
+			arg.ToAmount = quote.ToAmount
+			arg.ExchangeRate = quote.Rate
+		}
+
+		result, err = postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID:     arg.FromAccountID,
+			ToAccountID:       arg.ToAccountID,
+			Amount:            arg.Amount,
+			ToAmount:          arg.ToAmount,
+			ExchangeRate:      arg.ExchangeRate,
+			Description:       arg.Description,
+			ExternalReference: arg.ExternalReference,
+			Tags:              arg.Tags,
+			Fee:               arg.Fee,
 		})
 		if err != nil {
 			return err
 		}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+		// the source account row is locked by postTransfer until the commit
+		err = checkTransferLimits(ctx, q, result.FromAccount, arg.Amount)
 		if err != nil {
 			return err
 		}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-			if err != nil {
-				return err
-			}
-		} else {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-			if err != nil {
-				return err
-			}
+		if arg.IdempotencyKey != "" {
+			return saveIdempotentResponse(ctx, q, arg.Username, arg.IdempotencyKey, result)
 		}
 
 		return nil
@@ -68,17 +177,152 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 	return result, err
 }
 
-func addMoney(ctx context.Context, q *Queries, accountID1 int64, amount1 int64, accountID2 int64, amount2 int64) (account1 Account, account2 Account, err error) {
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		Amount: amount1,
-		ID:     accountID1,
+// postTransfer records a transfer with its two entries and moves the money.
+// The accounts are updated in id order, so concurrent transactions always
+// lock them in the same order and cannot deadlock. The system account
+// entries that balance the transfer are always locked last.
+func postTransfer(ctx context.Context, q *Queries, arg CreateTransferParams) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
+
+	// a nil slice would be stored as NULL, which the column rejects
+	if arg.Tags == nil {
+		arg.Tags = []string{}
+	}
+	result.Transfer, err = q.CreateTransfer(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	result.Fee = arg.Fee
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.FromAccountID,
+		Amount:     -(arg.Amount + arg.Fee),
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
 	})
 	if err != nil {
-		return
+		return result, err
 	}
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		Amount: amount2,
-		ID:     accountID2,
+
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.ToAccountID,
+		Amount:     arg.ToAmount,
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
 	})
+	if err != nil {
+		return result, err
+	}
+
+	debit := -(arg.Amount + arg.Fee)
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, debit, arg.ToAccountID, arg.ToAmount)
+		if err != nil {
+			return result, err
+		}
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.ToAmount, arg.FromAccountID, debit)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	err = postSystemLegs(ctx, q, result)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// systemLeg is an entry a transfer posts on a system account.
+type systemLeg struct {
+	purpose  string
+	currency string
+	amount   int64
+}
+
+// postSystemLegs balances a transfer in each currency with entries on the
+// system accounts: the fee is credited to the fee revenue account, and a
+// cross-currency transfer moves through the FX position accounts, which take
+// Amount in the source currency and give ToAmount in the destination one.
+// The system accounts are updated in id order after the customer accounts.
+func postSystemLegs(ctx context.Context, q *Queries, result TransferTxResult) error {
+	var legs []systemLeg
+	if result.Transfer.Fee > 0 {
+		legs = append(legs, systemLeg{SystemAccountFeeRevenue, result.FromAccount.Currency, result.Transfer.Fee})
+	}
+	if result.FromAccount.Currency != result.ToAccount.Currency {
+		legs = append(legs,
+			systemLeg{SystemAccountFXPosition, result.FromAccount.Currency, result.Transfer.Amount},
+			systemLeg{SystemAccountFXPosition, result.ToAccount.Currency, -result.Transfer.ToAmount},
+		)
+	}
+
+	type entry struct {
+		accountID int64
+		amount    int64
+	}
+	entries := make([]entry, 0, len(legs))
+	for _, leg := range legs {
+		accountID, err := q.GetSystemAccountID(ctx, GetSystemAccountIDParams{
+			Purpose:  leg.purpose,
+			Currency: leg.currency,
+		})
+		if err != nil {
+			return fmt.Errorf("get %s %s account:%w", leg.currency, leg.purpose, err)
+		}
+		entries = append(entries, entry{accountID, leg.amount})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].accountID < entries[j].accountID })
+
+	for _, e := range entries {
+		_, err := q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  e.accountID,
+			Amount:     e.amount,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = updateBalance(ctx, q, e.accountID, e.amount)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addMoney(ctx context.Context, q *Queries, accountID1 int64, amount1 int64, accountID2 int64, amount2 int64) (account1 Account, account2 Account, err error) {
+	account1, err = updateBalance(ctx, q, accountID1, amount1)
+	if err != nil {
+		return
+	}
+	account2, err = updateBalance(ctx, q, accountID2, amount2)
 	return
 }
+
+// updateBalance updates the account balance, checks the account status allows
+// the movement and, for debits, checks the overdraft limit against the available balance of the updated row, so funds
+// reserved by holds cannot be spent. The UPDATE holds the row lock until the
+// transaction ends, so concurrent transfers are checked against each other's
+// committed balance and can never push it below -overdraft_limit.
+func updateBalance(ctx context.Context, q *Queries, accountID int64, amount int64) (Account, error) {
+	account, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		Amount: amount,
+		ID:     accountID,
+	})
+	if err != nil {
+		return account, err
+	}
+
+	if err := checkAccountStatus(account, amount); err != nil {
+		return account, err
+	}
+
+	if amount < 0 && account.Balance-account.HeldAmount < -account.OverdraftLimit {
+		return account, ErrInsufficientFunds
+	}
+
+	return account, nil
+}
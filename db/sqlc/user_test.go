@@ -29,6 +29,9 @@ func createRandomUser(t *testing.T) User {
 	require.Equal(t, want.FullName, got.FullName)
 	require.Equal(t, want.Email, got.Email)
 
+	// every new user is a customer
+	require.Equal(t, util.RoleCustomer, got.Role)
+
 	require.NotZero(t, got.CreatedAt)
 	require.NotZero(t, got.PasswordChangedAt)
 	return got
@@ -53,6 +56,17 @@ func TestGetUser(t *testing.T) {
 	require.WithinDuration(t, want.PasswordChangedAt, got.PasswordChangedAt, time.Second)
 }
 
+func TestGetUserByEmail(t *testing.T) {
+	want := createRandomUser(t)
+	got, err := testQueries.GetUserByEmail(context.Background(), want.Email)
+	require.NoError(t, err)
+	require.Equal(t, want.Username, got.Username)
+	require.Equal(t, want.Email, got.Email)
+
+	_, err = testQueries.GetUserByEmail(context.Background(), util.RandomEmail())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func TestUpdateUserOnlyFullName(t *testing.T) {
 	oldUser := createRandomUser(t)
 	newFullName := util.RandomOwner()
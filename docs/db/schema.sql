@@ -10,6 +10,7 @@ CREATE TABLE "users" (
   -- this is also new field
   "is_email_verified" bool NOT NULL DEFAULT false,
   "password_changed_at" timestamptz NOT NULL DEFAULT '0001-01-01',
+  "role" varchar NOT NULL DEFAULT 'customer',
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -25,9 +26,17 @@ CREATE TABLE "verify_emails" (
 
 CREATE TABLE "accounts" (
   "id" bigserial PRIMARY KEY,
-  "owner" varchar NOT NULL,
+  "owner" varchar,
   "balance" bigint NOT NULL,
   "currency" varchar NOT NULL,
+  "overdraft_limit" bigint NOT NULL DEFAULT 0,
+  "held_amount" bigint NOT NULL DEFAULT 0,
+  "opening_balance" bigint NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "type" varchar NOT NULL DEFAULT 'checking',
+  "nickname" varchar NOT NULL DEFAULT '',
+  "closed_at" timestamptz,
+  "frozen_by" varchar,
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -35,7 +44,8 @@ CREATE TABLE "entries" (
   "id" bigserial PRIMARY KEY,
   "account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "transfer_id" bigint
 );
 
 CREATE TABLE "transfers" (
@@ -43,7 +53,15 @@ CREATE TABLE "transfers" (
   "from_account_id" bigint NOT NULL,
   "to_account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "to_amount" bigint NOT NULL,
+  "exchange_rate" numeric NOT NULL,
+  "reversal_of_id" bigint,
+  "reversed_amount" bigint NOT NULL DEFAULT 0,
+  "description" varchar NOT NULL DEFAULT '',
+  "external_reference" varchar NOT NULL DEFAULT '',
+  "tags" varchar[] NOT NULL DEFAULT '{}',
+  "fee" bigint NOT NULL DEFAULT 0
 );
 
 CREATE TABLE "sessions" (
@@ -54,35 +72,463 @@ CREATE TABLE "sessions" (
   "client_ip" varchar NOT NULL,
   "is_blocked" boolean NOT NULL DEFAULT false,
   "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "family_id" uuid NOT NULL,
+  "parent_id" uuid,
+  "consumed_at" timestamptz
+);
+
+CREATE TABLE "idempotency_keys" (
+  "username" varchar NOT NULL,
+  "key" varchar NOT NULL,
+  "request_hash" varchar NOT NULL,
+  "response" jsonb NOT NULL DEFAULT '{}',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "key")
+);
+
+CREATE TABLE "fx_quotes" (
+  "id" uuid PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "from_currency" varchar NOT NULL,
+  "to_currency" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "to_amount" bigint NOT NULL,
+  "rate" numeric NOT NULL,
+  "spread" numeric NOT NULL,
+  "is_used" bool NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL
+);
+
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  "numeric_code" int UNIQUE NOT NULL,
+  "minor_unit" int NOT NULL,
+  "enabled" bool NOT NULL DEFAULT true
+);
+
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL
+);
+
+CREATE TABLE "reconciliation_reports" (
+  "id" bigserial PRIMARY KEY,
+  "accounts_checked" bigint NOT NULL,
+  "transfers_checked" bigint NOT NULL,
+  "discrepancy_count" int NOT NULL,
+  "discrepancies" jsonb NOT NULL DEFAULT '[]',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "standing_orders" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "schedule" varchar NOT NULL,
+  "next_run_at" timestamptz NOT NULL,
+  "end_at" timestamptz,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "standing_order_executions" (
+  "id" bigserial PRIMARY KEY,
+  "standing_order_id" bigint NOT NULL,
+  "transfer_id" bigint,
+  "status" varchar NOT NULL,
+  "failure_reason" varchar NOT NULL DEFAULT '',
+  "run_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint,
+  "username" varchar,
+  "currency" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "item_count" int NOT NULL,
+  "total_amount" bigint NOT NULL,
+  "succeeded_count" int NOT NULL DEFAULT 0,
+  "failed_count" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+CREATE TABLE "batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "row_number" int NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "failure_reason" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint
+);
+
+CREATE TABLE "interest_rates" (
+  "id" bigserial PRIMARY KEY,
+  "account_type" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "annual_rate" numeric NOT NULL,
+  "effective_from" date NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate" numeric NOT NULL,
+  "amount" bigint NOT NULL,
+  "transfer_id" bigint,
+  "posted_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar NOT NULL,
+  "transfer_type" varchar NOT NULL,
+  "kind" varchar NOT NULL,
+  "flat_amount" bigint NOT NULL DEFAULT 0,
+  "percentage" numeric NOT NULL DEFAULT 0,
+  "min_amount" bigint NOT NULL DEFAULT 0,
+  "max_amount" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "system_accounts" (
+  "purpose" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "account_id" bigint UNIQUE NOT NULL,
+  PRIMARY KEY ("purpose", "currency")
+);
+
+CREATE TABLE "transfer_limits" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar,
+  "account_id" bigint,
+  "currency" varchar NOT NULL,
+  "max_single_amount" bigint NOT NULL,
+  "max_daily_amount" bigint NOT NULL,
+  "max_daily_count" int NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "security_events" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "kind" varchar NOT NULL,
+  "session_id" uuid,
+  "user_agent" varchar NOT NULL,
+  "client_ip" varchar NOT NULL,
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
 CREATE INDEX ON "accounts" ("owner");
 
-CREATE UNIQUE INDEX ON "accounts" ("owner", "currency");
+CREATE UNIQUE INDEX ON "accounts" ("owner", "currency", "type");
 
 CREATE INDEX ON "entries" ("account_id");
 
+CREATE INDEX ON "entries" ("account_id", "created_at", "id");
+
 CREATE INDEX ON "transfers" ("from_account_id");
 
 CREATE INDEX ON "transfers" ("to_account_id");
 
 CREATE INDEX ON "transfers" ("from_account_id", "to_account_id");
 
+CREATE INDEX ON "transfers" ("from_account_id", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("to_account_id", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("reversal_of_id");
+
+CREATE INDEX ON "transfers" USING GIN ("tags");
+
+CREATE INDEX ON "transfers" USING GIN (to_tsvector('simple', "description"));
+
+CREATE INDEX ON "holds" ("account_id");
+
+CREATE INDEX ON "holds" ("status", "expires_at");
+
+CREATE INDEX ON "standing_orders" ("owner");
+
+CREATE INDEX ON "standing_orders" ("status", "next_run_at");
+
+CREATE INDEX ON "standing_order_executions" ("standing_order_id", "id");
+
+CREATE UNIQUE INDEX ON "standing_order_executions" ("standing_order_id", "run_at");
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "nickname");
+
+CREATE INDEX ON "batches" ("owner");
+
+CREATE UNIQUE INDEX ON "batch_items" ("batch_id", "row_number");
+
+CREATE UNIQUE INDEX ON "interest_rates" ("account_type", "currency", "effective_from");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("account_id");
+
+CREATE UNIQUE INDEX ON "fee_schedules" ("currency", "transfer_type");
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("currency");
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("username", "currency");
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("account_id");
+
+CREATE INDEX ON "sessions" ("family_id");
+
+CREATE INDEX ON "security_events" ("username");
+
+COMMENT ON COLUMN "accounts"."owner" IS 'username, null for system accounts';
+
+COMMENT ON COLUMN "accounts"."overdraft_limit" IS 'how far below zero the balance may go';
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'sum of pending holds, not available to spend';
+
+COMMENT ON COLUMN "accounts"."opening_balance" IS 'balance the account was created with, before any entry';
+
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen or closed. frozen accounts only receive money, closed accounts do nothing';
+
+COMMENT ON COLUMN "accounts"."type" IS 'checking, savings or system';
+
+COMMENT ON COLUMN "accounts"."closed_at" IS 'set when the account is closed';
+
+COMMENT ON COLUMN "accounts"."frozen_by" IS 'user who froze the account, null for a freeze by the bank. only they can lift it';
+
 COMMENT ON COLUMN "entries"."amount" IS 'can be negative or positive';
 
+COMMENT ON COLUMN "entries"."transfer_id" IS 'transfer that posted this entry';
+
 COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
 
+COMMENT ON COLUMN "transfers"."to_amount" IS 'amount credited, in the destination account currency';
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'rate applied to amount to get to_amount';
+
+COMMENT ON COLUMN "transfers"."reversal_of_id" IS 'transfer this one refunds';
+
+COMMENT ON COLUMN "transfers"."reversed_amount" IS 'part of to_amount refunded so far';
+
+COMMENT ON COLUMN "transfers"."description" IS 'memo shown to both parties';
+
+COMMENT ON COLUMN "transfers"."external_reference" IS 'identifier in the client system, e.g. an invoice number';
+
+COMMENT ON COLUMN "transfers"."tags" IS 'labels to filter the transfer history on';
+
+COMMENT ON COLUMN "transfers"."fee" IS 'charged to the sender on top of amount and credited to the bank fee revenue account';
+
+COMMENT ON COLUMN "fx_quotes"."amount" IS 'amount debited, in from_currency';
+
+COMMENT ON COLUMN "fx_quotes"."to_amount" IS 'amount credited, in to_currency';
+
+COMMENT ON COLUMN "fx_quotes"."rate" IS 'rate offered to the user, spread included';
+
+COMMENT ON COLUMN "fx_quotes"."spread" IS 'fraction taken off the market rate';
+
 ALTER TABLE "verify_emails" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
 
 ALTER TABLE "user" ADD COLUMN ("username") REFERENCES "users" ("username");
 
 ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
 
+ALTER TABLE "accounts" ADD FOREIGN KEY ("frozen_by") REFERENCES "users" ("username");
+
 ALTER TABLE "entries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
 
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
 ALTER TABLE "transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "sessions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT ON COLUMN "currencies"."numeric_code" IS 'ISO 4217 numeric code';
+
+COMMENT ON COLUMN "currencies"."minor_unit" IS 'number of decimal places amounts are stored in';
+
+COMMENT ON COLUMN "currencies"."enabled" IS 'whether new accounts and transfers may use it';
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+COMMENT ON COLUMN "holds"."amount" IS 'amount reserved on account_id';
+
+COMMENT ON COLUMN "holds"."status" IS 'pending, captured or voided';
+
+COMMENT ON COLUMN "holds"."transfer_id" IS 'transfer that settled the hold once captured';
+
+COMMENT ON COLUMN "reconciliation_reports"."discrepancies" IS 'every inconsistency found, empty when the ledger balances';
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversal_of_id") REFERENCES "transfers" ("id");
+
+COMMENT ON COLUMN "standing_orders"."schedule" IS 'cron spec such as 0 9 1 * * or a descriptor such as @monthly or @every 168h';
+
+COMMENT ON COLUMN "standing_orders"."end_at" IS 'no run is scheduled after it, runs forever when null';
+
+COMMENT ON COLUMN "standing_orders"."status" IS 'active, paused, completed or cancelled';
+
+COMMENT ON COLUMN "standing_order_executions"."status" IS 'succeeded or failed';
+
+COMMENT ON COLUMN "standing_order_executions"."run_at" IS 'time the run was scheduled for';
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "standing_order_executions" ADD FOREIGN KEY ("standing_order_id") REFERENCES "standing_orders" ("id");
+
+ALTER TABLE "standing_order_executions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+COMMENT ON COLUMN "payees"."account_id" IS 'account paid, set when username is null';
+
+COMMENT ON COLUMN "payees"."username" IS 'user paid into their account in currency, set when account_id is null';
+
+COMMENT ON COLUMN "payees"."currency" IS 'currency credited to the payee';
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+COMMENT ON COLUMN "batches"."status" IS 'pending, processing or completed';
+
+COMMENT ON COLUMN "batches"."total_amount" IS 'sum of the items amount';
+
+COMMENT ON COLUMN "batch_items"."row_number" IS 'position of the item in the request, from 1';
+
+COMMENT ON COLUMN "batch_items"."amount" IS 'must be positive';
+
+COMMENT ON COLUMN "batch_items"."status" IS 'pending, succeeded or failed';
+
+COMMENT ON COLUMN "interest_rates"."annual_rate" IS 'fraction of the balance paid per year, e.g. 0.02 for 2%';
+
+COMMENT ON COLUMN "interest_rates"."effective_from" IS 'first day the rate applies, until a later rate takes over';
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'balance at the end of accrual_date';
+
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'interest earned on accrual_date, in minor units';
+
+COMMENT ON COLUMN "interest_accruals"."transfer_id" IS 'monthly credit that paid this accrual';
+
+COMMENT ON COLUMN "interest_accruals"."posted_at" IS 'set once the accrual is paid, or settled at zero';
+
+COMMENT ON COLUMN "fee_schedules"."transfer_type" IS 'same_currency or cross_currency';
+
+COMMENT ON COLUMN "fee_schedules"."kind" IS 'flat charges flat_amount, percentage charges percentage of the amount within min_amount and max_amount';
+
+COMMENT ON COLUMN "fee_schedules"."percentage" IS 'fraction of the amount, e.g. 0.01 for 1%';
+
+COMMENT ON COLUMN "fee_schedules"."max_amount" IS 'no cap when null';
+
+COMMENT ON COLUMN "system_accounts"."purpose" IS 'cash, fee_revenue, interest_expense, suspense or fx_position';
+
+COMMENT ON COLUMN "transfer_limits"."username" IS 'null for the bank default in currency';
+
+COMMENT ON COLUMN "transfer_limits"."account_id" IS 'null for the limits on all accounts of username in currency';
+
+COMMENT ON COLUMN "transfer_limits"."max_daily_amount" IS 'outgoing sum allowed per UTC day';
+
+COMMENT ON COLUMN "transfer_limits"."max_daily_count" IS 'outgoing transfers allowed per UTC day';
+
+COMMENT ON COLUMN "sessions"."family_id" IS 'id of the login session the refresh token was rotated from';
+
+COMMENT ON COLUMN "sessions"."parent_id" IS 'session whose refresh token was exchanged for this one';
+
+COMMENT ON COLUMN "sessions"."consumed_at" IS 'set when the refresh token is exchanged, presenting it again blocks the family';
+
+COMMENT ON COLUMN "security_events"."kind" IS 'refresh_token_reused';
+
+COMMENT ON COLUMN "security_events"."session_id" IS 'session the event was detected on';
+
+COMMENT ON COLUMN "users"."role" IS 'customer, support, admin or auditor. it decides which RPCs the user may call';
+
+ALTER TABLE "batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "batches" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "batches" ("id");
+
+ALTER TABLE "batch_items" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "interest_rates" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "system_accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "system_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "sessions" ADD FOREIGN KEY ("parent_id") REFERENCES "sessions" ("id");
+
+ALTER TABLE "security_events" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "security_events" ADD FOREIGN KEY ("session_id") REFERENCES "sessions" ("id");
@@ -0,0 +1,64 @@
+// Package fee computes the fees charged on transfers.
+//
+// A fee is charged in the currency of the source account, on top of the
+// amount sent. Percentage fees are rounded half to even to a whole minor
+// unit, then kept within the schedule minimum and maximum.
+package fee
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fx"
+)
+
+// Compute returns the fee a schedule charges on amount.
+func Compute(schedule db.FeeSchedule, amount int64) (int64, error) {
+	var fee int64
+	switch schedule.Kind {
+	case db.FeeKindFlat:
+		fee = schedule.FlatAmount
+	case db.FeeKindPercentage:
+		percentage, ok := new(big.Rat).SetString(schedule.Percentage)
+		if !ok || percentage.Sign() < 0 {
+			return 0, fmt.Errorf("invalid fee percentage %q", schedule.Percentage)
+		}
+		fee = fx.Convert(amount, percentage)
+		if fee < schedule.MinAmount {
+			fee = schedule.MinAmount
+		}
+		if schedule.MaxAmount.Valid && fee > schedule.MaxAmount.Int64 {
+			fee = schedule.MaxAmount.Int64
+		}
+	default:
+		return 0, fmt.Errorf("unknown fee kind %q", schedule.Kind)
+	}
+	return fee, nil
+}
+
+// TransferType tells which schedule applies to a transfer between currencies.
+func TransferType(fromCurrency, toCurrency string) string {
+	if fromCurrency != toCurrency {
+		return db.TransferTypeCrossCurrency
+	}
+	return db.TransferTypeSameCurrency
+}
+
+// ForTransfer returns the fee for a transfer of amount from an account in
+// fromCurrency to one in toCurrency. Transfers without a schedule are free.
+func ForTransfer(ctx context.Context, store db.Querier, fromCurrency, toCurrency string, amount int64) (int64, error) {
+	schedule, err := store.GetFeeSchedule(ctx, db.GetFeeScheduleParams{
+		Currency:     fromCurrency,
+		TransferType: TransferType(fromCurrency, toCurrency),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get fee schedule:%w", err)
+	}
+	return Compute(schedule, amount)
+}
@@ -0,0 +1,77 @@
+package fee
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	percentage := db.FeeSchedule{
+		Kind:       db.FeeKindPercentage,
+		Percentage: "0.01",
+		MinAmount:  100,
+		MaxAmount:  sql.NullInt64{Int64: 5000, Valid: true},
+	}
+
+	tcs := []struct {
+		name     string
+		schedule db.FeeSchedule
+		amount   int64
+		want     int64
+	}{
+		{name: "Flat", schedule: db.FeeSchedule{Kind: db.FeeKindFlat, FlatAmount: 250}, amount: 100000, want: 250},
+		{name: "Percentage", schedule: percentage, amount: 123456, want: 1235},
+		{name: "HalfToEven", schedule: percentage, amount: 12250, want: 122},
+		{name: "Minimum", schedule: percentage, amount: 500, want: 100},
+		{name: "Maximum", schedule: percentage, amount: 10000000, want: 5000},
+		{name: "NoMaximum", schedule: db.FeeSchedule{Kind: db.FeeKindPercentage, Percentage: "0.01"}, amount: 10000000, want: 100000},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fee, err := Compute(tc.schedule, tc.amount)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, fee)
+		})
+	}
+
+	_, err := Compute(db.FeeSchedule{Kind: "tiered"}, 100)
+	require.Error(t, err)
+}
+
+func TestForTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		GetFeeSchedule(gomock.Any(), gomock.Eq(db.GetFeeScheduleParams{
+			Currency:     util.USD,
+			TransferType: db.TransferTypeCrossCurrency,
+		})).
+		Times(1).
+		Return(db.FeeSchedule{Kind: db.FeeKindFlat, FlatAmount: 300}, nil)
+	store.EXPECT().
+		GetFeeSchedule(gomock.Any(), gomock.Eq(db.GetFeeScheduleParams{
+			Currency:     util.USD,
+			TransferType: db.TransferTypeSameCurrency,
+		})).
+		Times(1).
+		Return(db.FeeSchedule{}, sql.ErrNoRows)
+
+	fee, err := ForTransfer(context.Background(), store, util.USD, util.EUR, 1000)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), fee)
+
+	fee, err = ForTransfer(context.Background(), store, util.USD, util.USD, 1000)
+	require.NoError(t, err)
+	require.Zero(t, fee)
+}
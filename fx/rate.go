@@ -0,0 +1,147 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// ErrRateNotFound is returned when a provider has no rate for a currency pair.
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// RateProvider gives the rate to convert one unit of a currency into another.
+type RateProvider interface {
+	// Rate returns how many units of to are worth one unit of from.
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
+
+// StaticProvider serves a fixed set of rates, so it works offline.
+type StaticProvider struct {
+	rates map[string]*big.Rat
+}
+
+// NewStaticProvider creates a provider from rates keyed by "FROM/TO",
+// e.g. {"USD/BRL": "4.95"}. The inverse of each pair is derived when missing.
+func NewStaticProvider(rates map[string]string) (*StaticProvider, error) {
+	p := &StaticProvider{rates: make(map[string]*big.Rat, len(rates))}
+	for pair, value := range rates {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid currency pair %q", pair)
+		}
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate %q for %s", value, pair)
+		}
+		p.rates[pairKey(from, to)] = rate
+	}
+	return p, nil
+}
+
+// NewFileProvider creates a StaticProvider from a JSON file with the same
+// layout NewStaticProvider accepts.
+func NewFileProvider(path string) (*StaticProvider, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read rates file: %w", err)
+	}
+	var rates map[string]string
+	if err := json.Unmarshal(b, &rates); err != nil {
+		return nil, fmt.Errorf("cannot parse rates file: %w", err)
+	}
+	return NewStaticProvider(rates)
+}
+
+// Rate implements RateProvider. Converting a currency into itself is always 1.
+func (p *StaticProvider) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	if rate, ok := p.rates[pairKey(from, to)]; ok {
+		return new(big.Rat).Set(rate), nil
+	}
+	if rate, ok := p.rates[pairKey(to, from)]; ok {
+		return new(big.Rat).Inv(rate), nil
+	}
+	return nil, fmt.Errorf("%w: %s/%s", ErrRateNotFound, from, to)
+}
+
+func pairKey(from, to string) string {
+	return from + "/" + to
+}
+
+// Convert applies rate to amount, rounding half to even.
+func Convert(amount int64, rate *big.Rat) int64 {
+	return roundHalfEven(new(big.Rat).Mul(big.NewRat(amount, 1), rate))
+}
+
+func roundHalfEven(x *big.Rat) int64 {
+	quo, rem := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+	// compare 2*|rem| with the denominator to decide the rounding direction
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	cmp := twice.Cmp(x.Denom())
+	if cmp > 0 || (cmp == 0 && quo.Bit(0) == 1) {
+		if x.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo.Int64()
+}
+
+// FormatRate renders a rate as a decimal string suitable for a numeric column.
+func FormatRate(rate *big.Rat) string {
+	s := rate.FloatString(10)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// NewProvider loads rates from path, or returns a provider that only knows
+// same-currency conversions when path is empty.
+func NewProvider(path string) (RateProvider, error) {
+	if path == "" {
+		return NewStaticProvider(nil)
+	}
+	return NewFileProvider(path)
+}
+
+// ParseSpread parses the fraction taken off market rates, e.g. "0.005" for
+// half a percent. An empty string means no spread.
+func ParseSpread(s string) (*big.Rat, error) {
+	if s == "" {
+		return new(big.Rat), nil
+	}
+	spread, ok := new(big.Rat).SetString(s)
+	if !ok || spread.Sign() < 0 || spread.Cmp(big.NewRat(1, 1)) >= 0 {
+		return nil, fmt.Errorf("invalid spread %q", s)
+	}
+	return spread, nil
+}
+
+// ApplySpread returns the rate offered to users, rate * (1 - spread).
+func ApplySpread(rate, spread *big.Rat) *big.Rat {
+	factor := new(big.Rat).Sub(big.NewRat(1, 1), spread)
+	return factor.Mul(factor, rate)
+}
+
+// ConvertMinor applies a rate between major units to an amount in minor units,
+// for currencies with fromUnit and toUnit decimal places.
+func ConvertMinor(amount int64, rate *big.Rat, fromUnit, toUnit int32) int64 {
+	scaled := new(big.Rat).Set(rate)
+	if d := toUnit - fromUnit; d > 0 {
+		scaled.Mul(scaled, new(big.Rat).SetInt(pow10(d)))
+	} else if d < 0 {
+		scaled.Quo(scaled, new(big.Rat).SetInt(pow10(-d)))
+	}
+	return Convert(amount, scaled)
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
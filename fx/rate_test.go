@@ -0,0 +1,95 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dibrito/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticProvider(t *testing.T) {
+	p, err := NewStaticProvider(map[string]string{"USD/BRL": "5"})
+	require.NoError(t, err)
+
+	rate, err := p.Rate(context.Background(), util.USD, util.BRL)
+	require.NoError(t, err)
+	require.Equal(t, "5", FormatRate(rate))
+
+	rate, err = p.Rate(context.Background(), util.BRL, util.USD)
+	require.NoError(t, err)
+	require.Equal(t, "0.2", FormatRate(rate))
+
+	rate, err = p.Rate(context.Background(), util.EUR, util.EUR)
+	require.NoError(t, err)
+	require.Equal(t, "1", FormatRate(rate))
+
+	_, err = p.Rate(context.Background(), util.EUR, util.BRL)
+	require.True(t, errors.Is(err, ErrRateNotFound))
+}
+
+func TestNewStaticProviderInvalid(t *testing.T) {
+	_, err := NewStaticProvider(map[string]string{"USDBRL": "5"})
+	require.Error(t, err)
+
+	_, err = NewStaticProvider(map[string]string{"USD/BRL": "-1"})
+	require.Error(t, err)
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"EUR/USD": "1.08"}`), 0o600))
+
+	p, err := NewFileProvider(path)
+	require.NoError(t, err)
+
+	rate, err := p.Rate(context.Background(), util.EUR, util.USD)
+	require.NoError(t, err)
+	require.Equal(t, "1.08", FormatRate(rate))
+}
+
+func TestConvert(t *testing.T) {
+	tcs := []struct {
+		amount int64
+		rate   *big.Rat
+		want   int64
+	}{
+		{amount: 100, rate: big.NewRat(108, 100), want: 108},
+		{amount: 5, rate: big.NewRat(1, 2), want: 2},
+		{amount: 7, rate: big.NewRat(1, 2), want: 4},
+		{amount: 10, rate: big.NewRat(1, 3), want: 3},
+		{amount: -5, rate: big.NewRat(1, 2), want: -2},
+	}
+	for _, tc := range tcs {
+		require.Equal(t, tc.want, Convert(tc.amount, tc.rate))
+	}
+}
+
+func TestApplySpread(t *testing.T) {
+	spread, err := ParseSpread("0.01")
+	require.NoError(t, err)
+	require.Equal(t, "4.95", FormatRate(ApplySpread(big.NewRat(5, 1), spread)))
+
+	spread, err = ParseSpread("")
+	require.NoError(t, err)
+	require.Equal(t, "5", FormatRate(ApplySpread(big.NewRat(5, 1), spread)))
+
+	_, err = ParseSpread("1")
+	require.Error(t, err)
+
+	_, err = ParseSpread("-0.1")
+	require.Error(t, err)
+}
+
+func TestConvertMinor(t *testing.T) {
+	// 10.00 USD to JPY at 150
+	require.Equal(t, int64(1500), ConvertMinor(1000, big.NewRat(150, 1), 2, 0))
+	// 1500 JPY to USD cents
+	require.Equal(t, int64(1000), ConvertMinor(1500, big.NewRat(1, 150), 0, 2))
+	// same exponent is a plain conversion
+	require.Equal(t, Convert(1000, big.NewRat(108, 100)), ConvertMinor(1000, big.NewRat(108, 100), 2, 2))
+}
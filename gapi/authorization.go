@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"github.com/dibrito/simple-bank/token"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -14,7 +16,34 @@ const (
 	authorizationBearer = "bearer"
 )
 
+// authorizeUser returns the payload of the access token of ctx, if its role
+// may call the RPC served. It fails with PermissionDenied if ctx does not
+// tell which RPC is served.
 func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
+	// the interceptor already checked the token
+	if payload, ok := ctx.Value(payloadKey{}).(*token.Payload); ok {
+		return payload, nil
+	}
+
+	payload, err := server.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// without the RPC its permissions cannot be checked, so nobody may call it
+	method, ok := rpcMethod(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "unknown RPC")
+	}
+	err = checkPermission(method, payload.Role)
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// authenticate returns the payload of the access token of ctx.
+func (server *Server) authenticate(ctx context.Context) (*token.Payload, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, fmt.Errorf("missing metadata")
@@ -43,5 +72,10 @@ func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error)
 		return nil, fmt.Errorf("invalid access token:%s", err)
 	}
 
-	return payload, err
+	err = server.revoker.CheckToken(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access token:%s", err)
+	}
+
+	return payload, nil
 }
@@ -0,0 +1,151 @@
+package gapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dibrito/simple-bank/money"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxBatchCSVSize bounds the upload, a full batch takes well under it.
+const maxBatchCSVSize = 1 << 20
+
+var batchCSVHeader = []string{"to_account_id", "amount"}
+
+// BatchCSVHandler creates a batch transfer from a CSV upload, for payroll
+// exports that can't build the CreateBatchTransfer JSON body.
+//
+//	POST /v1/batches/csv?from_account_id=1&currency=USD
+//	to_account_id,amount
+//	2,1500.00
+//
+// Amounts are decimal strings in the batch currency. Rows are reported as
+// items[i], i counting the rows after the header from 0.
+// The response is the same as CreateBatchTransfer's.
+func BatchCSVHandler(server *Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			st := status.Newf(codes.Unimplemented, "method %s not allowed", r.Method)
+			writeBatchCSVResponse(w, http.StatusMethodNotAllowed, st.Proto())
+			return
+		}
+
+		req, violations := parseBatchCSV(w, r)
+		if violations != nil {
+			writeBatchCSVError(w, invalidArgumentsError(violations))
+			return
+		}
+
+		// the handler sits beside the gateway, so it forwards the headers
+		// the RPC reads itself
+		md := metadata.MD{}
+		for key, values := range r.Header {
+			if name, ok := HeaderMatcher(key); ok {
+				md.Append(name, values...)
+			}
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			md.Set(authorizationHeader, auth)
+		}
+		ctx := metadata.NewIncomingContext(r.Context(), md)
+		ctx = withRPCMethod(ctx, pb.SimpleBank_CreateBatchTransfer_FullMethodName)
+
+		resp, err := server.CreateBatchTransfer(ctx, req)
+		if err != nil {
+			writeBatchCSVError(w, err)
+			return
+		}
+		writeBatchCSVResponse(w, http.StatusOK, resp)
+	})
+}
+
+func parseBatchCSV(w http.ResponseWriter, r *http.Request) (req *pb.CreateBatchTransferRequest, violations []*errdetails.BadRequest_FieldViolation) {
+	req = &pb.CreateBatchTransferRequest{
+		Currency: r.URL.Query().Get("currency"),
+	}
+
+	fromAccountID, err := strconv.ParseInt(r.URL.Query().Get("from_account_id"), 10, 64)
+	if err != nil {
+		violations = append(violations, fieldViolation("from_account_id", errors.New("must be a positive integer")))
+	}
+	req.FromAccountId = fromAccountID
+
+	// amounts can't be parsed without a known currency
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+		return req, violations
+	}
+
+	reader := csv.NewReader(http.MaxBytesReader(w, r.Body, maxBatchCSVSize))
+	reader.FieldsPerRecord = len(batchCSVHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil || !strings.EqualFold(strings.Join(header, ","), strings.Join(batchCSVHeader, ",")) {
+		violations = append(violations, fieldViolation("body", fmt.Errorf("must be a CSV file with header %q", strings.Join(batchCSVHeader, ","))))
+		return req, violations
+	}
+
+	for i := 0; ; i++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			violations = append(violations, fieldViolation("body", err))
+			return req, violations
+		}
+		if i == maxBatchItems {
+			violations = append(violations, fieldViolation("items", fmt.Errorf("must hold from %d to %d items", 1, maxBatchItems)))
+			return req, violations
+		}
+
+		toAccountID, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("items[%d].to_account_id", i), errors.New("must be a positive integer")))
+		}
+		amount, err := money.Parse(record[1], req.GetCurrency())
+		if err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("items[%d].amount", i), err))
+		}
+
+		req.Items = append(req.Items, &pb.BatchTransferItem{
+			ToAccountId: toAccountID,
+			Amount:      amount.Amount,
+		})
+	}
+
+	return req, violations
+}
+
+// writeBatchCSVError writes err the way the gateway writes RPC errors.
+func writeBatchCSVError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeBatchCSVResponse(w, runtime.HTTPStatusFromCode(st.Code()), st.Proto())
+}
+
+func writeBatchCSVResponse(w http.ResponseWriter, code int, msg proto.Message) {
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		code = http.StatusInternalServerError
+		body = []byte(`{"code":13,"message":"failed to marshal response"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
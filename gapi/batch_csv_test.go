@@ -0,0 +1,161 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	mockwk "github.com/dibrito/simple-bank/worker/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCSVHandler(t *testing.T) {
+	user, _ := randomUser(t)
+	account1 := randomAccount(user.Username)
+	account2 := randomAccount(util.RandomOwner())
+	account1.ID, account2.ID = 1, 2
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+
+	validBody := "to_account_id,amount\n2,12.34\n2, 0.66\n"
+
+	withToken := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		accessToken, _, err := tokenMaker.CreateToken(user.Username, util.RoleCustomer, time.Minute)
+		require.NoError(t, err)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	}
+
+	tcs := []struct {
+		name          string
+		method        string
+		query         string
+		body          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			method:    http.MethodPost,
+			query:     "from_account_id=1&currency=USD",
+			body:      validBody,
+			setupAuth: withToken,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					CreateBatchTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateBatchTxParams) (db.CreateBatchTxResult, error) {
+						require.Equal(t, []db.BatchItemParams{
+							{ToAccountID: account2.ID, Amount: 1234},
+							{ToAccountID: account2.ID, Amount: 66},
+						}, arg.Items)
+						batch := db.Batch{ID: 7, Owner: arg.Owner, Status: db.BatchStatusPending, ItemCount: 2, TotalAmount: 1300}
+						if err := arg.AfterCreate(batch); err != nil {
+							return db.CreateBatchTxResult{}, err
+						}
+						return db.CreateBatchTxResult{Batch: batch}, nil
+					})
+				taskDistributor.EXPECT().DistributeTaskProcessBatch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var body struct {
+					Batch struct {
+						ID          string `json:"id"`
+						TotalAmount string `json:"total_amount"`
+					} `json:"batch"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, "7", body.Batch.ID)
+				require.Equal(t, "1300", body.Batch.TotalAmount)
+			},
+		},
+		{
+			name:      "InvalidRows",
+			method:    http.MethodPost,
+			query:     "from_account_id=1&currency=USD",
+			body:      "to_account_id,amount\nabc,1.00\n2,1.234\n",
+			setupAuth: withToken,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreateBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "items[0].to_account_id")
+				require.Contains(t, recorder.Body.String(), "items[1].amount")
+			},
+		},
+		{
+			name:      "MissingHeader",
+			method:    http.MethodPost,
+			query:     "from_account_id=1&currency=USD",
+			body:      "2,12.34\n",
+			setupAuth: withToken,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreateBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "NoAuthorization",
+			method:    http.MethodPost,
+			query:     "from_account_id=1&currency=USD",
+			body:      validBody,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreateBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "MethodNotAllowed",
+			method:    http.MethodGet,
+			setupAuth: withToken,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreateBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrlStore := gomock.NewController(t)
+			defer ctrlStore.Finish()
+			store := mockdb.NewMockStore(ctrlStore)
+
+			ctrlTaskDistributor := gomock.NewController(t)
+			defer ctrlTaskDistributor.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrlTaskDistributor)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServer(t, store, taskDistributor)
+
+			request := httptest.NewRequest(tc.method, "/v1/batches/csv?"+tc.query, strings.NewReader(tc.body))
+			tc.setupAuth(t, request, server.tokenMaker)
+			recorder := httptest.NewRecorder()
+
+			BatchCSVHandler(server).ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -26,5 +26,9 @@ func invalidArgumentsError(violations []*errdetails.BadRequest_FieldViolation) e
 }
 
 func unauthenticatedError(err error) error {
+	// an authenticated user without permission is told so
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
 	return status.Errorf(codes.Unauthenticated, "unauthorized:%s", err)
 }
@@ -0,0 +1,38 @@
+package gapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dibrito/simple-bank/token"
+)
+
+// JWKSHandler serves the public keys the server's tokens are signed with as
+// a JSON Web Key Set, so other services can verify them without our secret.
+// The set is empty when tokens are signed with the symmetric key.
+//
+//	GET /.well-known/jwks.json
+func JWKSHandler(server *Server) http.Handler {
+	set := token.JSONWebKeySet{Keys: []token.JSONWebKey{}}
+	if maker, ok := server.tokenMaker.(token.PublicKeyMaker); ok {
+		set = maker.Keys().JWKS()
+	}
+	body, err := json.Marshal(set)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", http.MethodGet)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// keys change only on a restart, a rotation can wait for the cache
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Write(body)
+	})
+}
@@ -0,0 +1,86 @@
+package gapi
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dibrito/simple-bank/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys, err := token.NewKeySet(base64.StdEncoding.EncodeToString(private.Seed()), nil)
+	require.NoError(t, err)
+
+	tcs := []struct {
+		name          string
+		method        string
+		buildMaker    func(t *testing.T) token.Maker
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "PublicKeyMaker",
+			method: http.MethodGet,
+			buildMaker: func(t *testing.T) token.Maker {
+				maker, err := token.NewPasetoPublicMaker(keys)
+				require.NoError(t, err)
+				return maker
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+				var set token.JSONWebKeySet
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &set))
+				require.Len(t, set.Keys, 1)
+				require.Equal(t, token.KeyID(public), set.Keys[0].Kid)
+				require.Equal(t, base64.RawURLEncoding.EncodeToString(public), set.Keys[0].X)
+			},
+		},
+		{
+			name:   "SymmetricMaker",
+			method: http.MethodGet,
+			buildMaker: func(t *testing.T) token.Maker {
+				return nil
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"keys":[]}`, recorder.Body.String())
+			},
+		},
+		{
+			name:   "MethodNotAllowed",
+			method: http.MethodPost,
+			buildMaker: func(t *testing.T) token.Maker {
+				return nil
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, nil, nil)
+			if maker := tc.buildMaker(t); maker != nil {
+				server.tokenMaker = maker
+			}
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(tc.method, "/.well-known/jwks.json", nil)
+			require.NoError(t, err)
+
+			JWKSHandler(server).ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
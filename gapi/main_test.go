@@ -1,12 +1,18 @@
 package gapi
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
 	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fx"
+	"github.com/dibrito/simple-bank/token"
 	"github.com/dibrito/simple-bank/util"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/dibrito/simple-bank/worker"
 )
@@ -14,10 +20,42 @@ import (
 // NewServer creates a new gRPC server.
 func newTestServer(t *testing.T, store db.Store, td worker.TaskDistributor) *Server {
 	config := util.Config{
-		TokenSymmetricKey: util.RandomString(32),
-		AccessDuration:    time.Minute,
+		TokenSymmetricKey:     util.RandomString(32),
+		AccessDuration:        time.Minute,
+		RefreshDuration:       time.Hour,
+		FXSpread:              "0.01",
+		FXQuoteDuration:       time.Minute,
+		HoldDuration:          time.Hour,
+		PayeeCoolingOffPeriod: 24 * time.Hour,
+		PayeeCoolingOffLimit:  1000,
 	}
-	server, err := NewServer(config, store, td)
+	server, err := NewServer(config, store, td, token.NewMemoryRevoker(time.Hour))
+	require.NoError(t, err)
+
+	server.rates, err = fx.NewStaticProvider(map[string]string{"EUR/USD": "1.08"})
 	require.NoError(t, err)
 	return server
 }
+
+// newContextWithBearerToken returns an incoming context carrying an access token for username with role.
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username, role string, duration time.Duration) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(username, role, duration)
+	require.NoError(t, err)
+
+	bearerToken := fmt.Sprintf("%s %s", authorizationBearer, accessToken)
+	md := metadata.MD{
+		authorizationHeader: []string{bearerToken},
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func randomAccount(owner string) db.Account {
+	return db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    sql.NullString{String: owner, Valid: true},
+		Balance:  util.RandonMoney(),
+		Currency: util.RandonCurrency(),
+		Status:   db.AccountStatusActive,
+		Type:     db.AccountTypeChecking,
+	}
+}
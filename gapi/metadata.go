@@ -2,7 +2,9 @@ package gapi
 
 import (
 	"context"
+	"strings"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
@@ -11,11 +13,13 @@ const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	userAgentHeader            = "user-agent"
 	grpcGatewayClientIP        = "x-forwarded-for"
+	idempotencyKeyHeader       = "idempotency-key"
 )
 
 type Metadata struct {
-	UserAgent string
-	ClientIP  string
+	UserAgent      string
+	ClientIP       string
+	IdempotencyKey string
 }
 
 func (server *Server) extractMetadata(ctx context.Context) *Metadata {
@@ -32,6 +36,10 @@ func (server *Server) extractMetadata(ctx context.Context) *Metadata {
 		if clientIPs := md.Get(grpcGatewayClientIP); len(clientIPs) > 0 {
 			mtdt.ClientIP = clientIPs[0]
 		}
+
+		if keys := md.Get(idempotencyKeyHeader); len(keys) > 0 {
+			mtdt.IdempotencyKey = keys[0]
+		}
 	}
 
 	if p, ok := peer.FromContext(ctx); ok {
@@ -39,3 +47,12 @@ func (server *Server) extractMetadata(ctx context.Context) *Metadata {
 	}
 	return mtdt
 }
+
+// HeaderMatcher forwards the Idempotency-Key HTTP header to the gRPC metadata,
+// which the gateway would otherwise drop, and keeps the default rules for the rest.
+func HeaderMatcher(key string) (string, bool) {
+	if strings.ToLower(key) == idempotencyKeyHeader {
+		return idempotencyKeyHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
@@ -0,0 +1,139 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// publicRPC needs no access token
+	publicRPC []string
+	// anyRole is anyone signed in, managing their own user and sessions
+	anyRole      = []string{util.RoleCustomer, util.RoleSupport, util.RoleAdmin, util.RoleAuditor}
+	customerRole = []string{util.RoleCustomer}
+)
+
+// permissions lists the roles allowed to call each RPC. An RPC missing from
+// it is denied to everyone, so a new RPC is unreachable until it is listed.
+// Handlers still check that the resources they touch belong to the caller.
+var permissions = map[string][]string{
+	pb.SimpleBank_CreateUser_FullMethodName:       publicRPC,
+	pb.SimpleBank_LoginUser_FullMethodName:        publicRPC,
+	pb.SimpleBank_LogoutUser_FullMethodName:       publicRPC,
+	pb.SimpleBank_RenewAccessToken_FullMethodName: publicRPC,
+	pb.SimpleBank_VerifyEmail_FullMethodName:      publicRPC,
+	pb.SimpleBank_ListCurrencies_FullMethodName:   publicRPC,
+
+	pb.SimpleBank_UpdateUser_FullMethodName:    anyRole,
+	pb.SimpleBank_ListSessions_FullMethodName:  anyRole,
+	pb.SimpleBank_RevokeSession_FullMethodName: anyRole,
+
+	pb.SimpleBank_CreateAccount_FullMethodName:       customerRole,
+	pb.SimpleBank_GetAccount_FullMethodName:          customerRole,
+	pb.SimpleBank_ListAccounts_FullMethodName:        customerRole,
+	pb.SimpleBank_UpdateAccount_FullMethodName:       customerRole,
+	pb.SimpleBank_CloseAccount_FullMethodName:        customerRole,
+	pb.SimpleBank_ListEntries_FullMethodName:         customerRole,
+	pb.SimpleBank_CreateTransfer_FullMethodName:      customerRole,
+	pb.SimpleBank_PreviewTransfer_FullMethodName:     customerRole,
+	pb.SimpleBank_ListTransfers_FullMethodName:       customerRole,
+	pb.SimpleBank_CreateQuote_FullMethodName:         customerRole,
+	pb.SimpleBank_CreateBatchTransfer_FullMethodName: customerRole,
+	pb.SimpleBank_GetBatch_FullMethodName:            customerRole,
+	pb.SimpleBank_CreateHold_FullMethodName:          customerRole,
+	pb.SimpleBank_CaptureHold_FullMethodName:         customerRole,
+	pb.SimpleBank_VoidHold_FullMethodName:            customerRole,
+	pb.SimpleBank_CreatePayee_FullMethodName:         customerRole,
+	pb.SimpleBank_GetPayee_FullMethodName:            customerRole,
+	pb.SimpleBank_ListPayees_FullMethodName:          customerRole,
+	pb.SimpleBank_UpdatePayee_FullMethodName:         customerRole,
+	pb.SimpleBank_DeletePayee_FullMethodName:         customerRole,
+	pb.SimpleBank_ResolvePayee_FullMethodName:        customerRole,
+	pb.SimpleBank_CreateStandingOrder_FullMethodName: customerRole,
+	pb.SimpleBank_GetStandingOrder_FullMethodName:    customerRole,
+	pb.SimpleBank_ListStandingOrders_FullMethodName:  customerRole,
+	pb.SimpleBank_UpdateStandingOrder_FullMethodName: customerRole,
+	pb.SimpleBank_CancelStandingOrder_FullMethodName: customerRole,
+	pb.SimpleBank_GetTransferLimit_FullMethodName:    customerRole,
+	pb.SimpleBank_UpdateTransferLimit_FullMethodName: customerRole,
+
+	// customers give back what they received, admins reverse any transfer
+	pb.SimpleBank_ReverseTransfer_FullMethodName:         {util.RoleCustomer, util.RoleAdmin},
+	pb.SimpleBank_GetReconciliationReport_FullMethodName: {util.RoleAdmin, util.RoleAuditor},
+}
+
+type payloadKey struct{}
+
+// AuthInterceptor checks the permissions of an RPC before its handler runs,
+// and hands the payload of the access token to authorizeUser.
+// The gateway calls the handlers without interceptors; there authorizeUser
+// checks the permissions itself, reading the RPC from the context.
+func AuthInterceptor(server *Server) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		roles, ok := permissions[info.FullMethod]
+		if ok && roles == nil {
+			return handler(ctx, req)
+		}
+
+		payload, err := server.authenticate(ctx)
+		if err != nil {
+			return nil, unauthenticatedError(err)
+		}
+		err = checkPermission(info.FullMethod, payload.Role)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, payloadKey{}, payload), req)
+	}
+}
+
+// checkPermission returns a PermissionDenied error if role may not call
+// method. Anyone may call a public RPC.
+func checkPermission(method, role string) error {
+	roles, ok := permissions[method]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "%s is not allowed to anyone", method)
+	}
+	if roles == nil {
+		return nil
+	}
+	for _, allowed := range roles {
+		if allowed == role {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "role %q is not allowed to call %s", role, method)
+}
+
+// rpcMethod returns the full name of the RPC served with ctx, through gRPC
+// or the gateway.
+func rpcMethod(ctx context.Context) (string, bool) {
+	if method, ok := grpc.Method(ctx); ok && method != "" {
+		return method, true
+	}
+	return runtime.RPCMethod(ctx)
+}
+
+// methodStream only names the RPC of a context built outside gRPC.
+type methodStream struct {
+	method string
+}
+
+func (s *methodStream) Method() string                  { return s.method }
+func (s *methodStream) SetHeader(md metadata.MD) error  { return nil }
+func (s *methodStream) SendHeader(md metadata.MD) error { return nil }
+func (s *methodStream) SetTrailer(md metadata.MD) error { return nil }
+
+// withRPCMethod returns a copy of ctx serving method, for the handlers
+// called outside gRPC and the gateway so their permissions are checked.
+func withRPCMethod(ctx context.Context, method string) context.Context {
+	return grpc.NewContextWithServerTransportStream(ctx, &methodStream{method: method})
+}
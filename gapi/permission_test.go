@@ -0,0 +1,126 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPermissionsListEveryRPC(t *testing.T) {
+	for _, method := range pb.SimpleBank_ServiceDesc.Methods {
+		fullMethod := "/" + pb.SimpleBank_ServiceDesc.ServiceName + "/" + method.MethodName
+		_, ok := permissions[fullMethod]
+		require.True(t, ok, "%s has no permissions", fullMethod)
+	}
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	username := util.RandomOwner()
+	withRole := func(role string) func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return func(t *testing.T, tokenMaker token.Maker) context.Context {
+			return newContextWithBearerToken(t, tokenMaker, username, role, time.Minute)
+		}
+	}
+
+	tcs := []struct {
+		name         string
+		method       string
+		buildContext func(t *testing.T, tokenMaker token.Maker) context.Context
+		// code is codes.OK when the handler must run
+		code codes.Code
+	}{
+		{
+			name:         "Allowed",
+			method:       pb.SimpleBank_CreateAccount_FullMethodName,
+			buildContext: withRole(util.RoleCustomer),
+			code:         codes.OK,
+		},
+		{
+			name:         "RoleDenied",
+			method:       pb.SimpleBank_CreateAccount_FullMethodName,
+			buildContext: withRole(util.RoleAuditor),
+			code:         codes.PermissionDenied,
+		},
+		{
+			name:         "AdminOnly",
+			method:       pb.SimpleBank_GetReconciliationReport_FullMethodName,
+			buildContext: withRole(util.RoleCustomer),
+			code:         codes.PermissionDenied,
+		},
+		{
+			name:   "Public",
+			method: pb.SimpleBank_LoginUser_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			code: codes.OK,
+		},
+		{
+			name:   "NoAuthorization",
+			method: pb.SimpleBank_CreateAccount_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:         "NotListed",
+			method:       "/pb.SimpleBank/DeleteBank",
+			buildContext: withRole(util.RoleAdmin),
+			code:         codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, nil, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				// the handler gets the payload without verifying the token again
+				_, err := server.authorizeUser(ctx)
+				if tc.method != pb.SimpleBank_LoginUser_FullMethodName {
+					require.NoError(t, err)
+				}
+				return nil, nil
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+			_, err := AuthInterceptor(server)(ctx, nil, info, handler)
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.code == codes.OK, called)
+		})
+	}
+}
+
+func TestAuthorizeUserChecksRPC(t *testing.T) {
+	server := newTestServer(t, nil, nil)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, util.RandomOwner(), util.RoleSupport, time.Minute)
+
+	// the gateway calls the handlers without the interceptor
+	_, err := server.authorizeUser(withRPCMethod(ctx, pb.SimpleBank_UpdateUser_FullMethodName))
+	require.NoError(t, err)
+
+	// only the recipient or an admin may reverse a transfer
+	_, err = server.authorizeUser(withRPCMethod(ctx, pb.SimpleBank_ReverseTransfer_FullMethodName))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.authorizeUser(withRPCMethod(ctx, pb.SimpleBank_CreateTransfer_FullMethodName))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// a context that does not name the RPC is denied
+	_, err = server.authorizeUser(ctx)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Equal(t, codes.PermissionDenied, status.Code(unauthenticatedError(err)))
+}
@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CancelStandingOrder stops an order for good. The row is kept, it is
+// referenced by the order's executions.
+func (server *Server) CancelStandingOrder(ctx context.Context, req *pb.CancelStandingOrderRequest) (*pb.CancelStandingOrderResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCancelStandingOrderRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	order, err := server.ownedStandingOrder(ctx, payload.Username, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == db.StandingOrderStatusCompleted || order.Status == db.StandingOrderStatusCancelled {
+		return nil, status.Errorf(codes.FailedPrecondition, "standing order is %s", order.Status)
+	}
+
+	order, err = server.store.UpdateStandingOrder(ctx, db.UpdateStandingOrderParams{
+		ID:     order.ID,
+		Status: sql.NullString{String: db.StandingOrderStatusCancelled, Valid: true},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to cancel standing order:%s", err)
+	}
+
+	resp := &pb.CancelStandingOrderResponse{
+		StandingOrder: convertStandingOrder(order),
+	}
+	return resp, nil
+}
+
+func validateCancelStandingOrderRequest(req *pb.CancelStandingOrderRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,72 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fee"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CaptureHold(ctx context.Context, req *pb.CaptureHoldRequest) (*pb.CaptureHoldResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCaptureHoldRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	hold, account, err := server.ownedHold(ctx, payload.Username, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	// holds are only placed between accounts of the same currency
+	amount := req.GetAmount()
+	if amount == 0 {
+		amount = hold.Amount
+	}
+	transferFee, err := fee.ForTransfer(ctx, server.store, account.Currency, account.Currency, amount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to compute fee:%s", err)
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Amount: req.GetAmount(),
+		Fee:    transferFee,
+	})
+	if err != nil {
+		return nil, holdError(err)
+	}
+
+	resp := &pb.CaptureHoldResponse{
+		Hold:        convertHold(result.Hold),
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+	return resp, nil
+}
+
+func validateCaptureHoldRequest(req *pb.CaptureHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if req.GetAmount() < 0 {
+		violations = append(violations, fieldViolation("amount", errors.New("must not be negative")))
+	}
+
+	return violations
+}
@@ -0,0 +1,212 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomHold(from, to db.Account) db.Hold {
+	return db.Hold{
+		ID:          from.ID + to.ID,
+		AccountID:   from.ID,
+		ToAccountID: to.ID,
+		Amount:      10,
+		Status:      db.HoldStatusPending,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+}
+
+func TestCaptureHoldApi(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	hold := randomHold(account1, account2)
+
+	withUser1 := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, util.RoleCustomer, time.Minute)
+	}
+
+	tcs := []struct {
+		name          string
+		req           *pb.CaptureHoldRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CaptureHoldResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.CaptureHoldRequest{Id: hold.ID, Amount: 6},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+
+				store.EXPECT().
+					GetFeeSchedule(gomock.Any(), gomock.Eq(db.GetFeeScheduleParams{Currency: account1.Currency, TransferType: db.TransferTypeSameCurrency})).
+					Times(1).
+					Return(db.FeeSchedule{Kind: db.FeeKindFlat, FlatAmount: 1}, nil)
+
+				captured := hold
+				captured.Status = db.HoldStatusCaptured
+				captured.TransferID = sql.NullInt64{Int64: 1, Valid: true}
+				store.EXPECT().
+					CaptureHoldTx(gomock.Any(), gomock.Eq(db.CaptureHoldTxParams{HoldID: hold.ID, Amount: 6, Fee: 1})).
+					Times(1).
+					Return(db.CaptureHoldTxResult{
+						Hold: captured,
+						TransferTxResult: db.TransferTxResult{
+							Transfer:    db.Transfer{ID: 1, FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 6},
+							FromAccount: account1,
+							ToAccount:   account2,
+						},
+					}, nil)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CaptureHoldResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.HoldStatusCaptured, res.GetHold().GetStatus())
+				require.Equal(t, int64(1), res.GetHold().GetTransferId())
+				require.Equal(t, int64(6), res.GetTransfer().GetAmount())
+			},
+		},
+		{
+			name: "NotPending",
+			req:  &pb.CaptureHoldRequest{Id: hold.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, sql.ErrNoRows)
+				store.EXPECT().
+					CaptureHoldTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CaptureHoldTxResult{}, db.ErrHoldNotPending)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CaptureHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			// the fee is charged on top of the captured amount
+			name: "InsufficientFundsForFee",
+			req:  &pb.CaptureHoldRequest{Id: hold.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, sql.ErrNoRows)
+				store.EXPECT().
+					CaptureHoldTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CaptureHoldTxResult{}, db.ErrInsufficientFunds)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CaptureHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+				require.Equal(t, db.ErrInsufficientFunds.Error(), st.Message())
+			},
+		},
+		{
+			name: "ExceedsHold",
+			req:  &pb.CaptureHoldRequest{Id: hold.ID, Amount: hold.Amount + 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, sql.ErrNoRows)
+				store.EXPECT().
+					CaptureHoldTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CaptureHoldTxResult{}, db.ErrCaptureExceedsHold)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CaptureHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "NotFound",
+			req:  &pb.CaptureHoldRequest{Id: hold.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(db.Hold{}, sql.ErrNoRows)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CaptureHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req:  &pb.CaptureHoldRequest{Id: hold.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CaptureHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "NegativeAmount",
+			req:  &pb.CaptureHoldRequest{Id: hold.ID, Amount: -1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CaptureHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_CaptureHold_FullMethodName)
+			res, err := server.CaptureHold(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CloseAccount(ctx context.Context, req *pb.CloseAccountRequest) (*pb.CloseAccountResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCloseAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	acc, err := server.getOwnedAccount(ctx, payload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.CloseAccountTx(ctx, db.CloseAccountTxParams{
+		AccountID:        acc.ID,
+		SweepToAccountID: req.GetSweepToAccountId(),
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			return nil, status.Errorf(codes.NotFound, "sweep account not found")
+		case errors.Is(err, db.ErrInvalidSweepAccount):
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		case errors.Is(err, db.ErrAccountNotEmpty),
+			errors.Is(err, db.ErrAccountClosed),
+			errors.Is(err, db.ErrAccountFrozen):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "fail to close account:%s", err)
+	}
+
+	resp := &pb.CloseAccountResponse{
+		Account: convertAccount(result.Account),
+	}
+	if result.Sweep != nil {
+		resp.Sweep = convertTransfer(result.Sweep.Transfer)
+	}
+	return resp, nil
+}
+
+func validateCloseAccountRequest(req *pb.CloseAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if req.GetSweepToAccountId() != 0 {
+		if err := val.ValidateAccountId(req.GetSweepToAccountId()); err != nil {
+			violations = append(violations, fieldViolation("sweep_to_account_id", err))
+		}
+	}
+
+	return violations
+}
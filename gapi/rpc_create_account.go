@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/money"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	accountType := req.GetType()
+	if accountType == "" {
+		accountType = db.AccountTypeChecking
+	}
+
+	acc, err := server.store.CreateAccount(ctx, db.CreateAccountParams{
+		Owner:    payload.Username,
+		Currency: req.GetCurrency(),
+		Type:     accountType,
+		Nickname: req.GetNickname(),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "account already exists:%s", err)
+			case "foreign_key_violation":
+				return nil, status.Errorf(codes.NotFound, "owner not found:%s", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "fail to create account:%s", err)
+	}
+
+	resp := &pb.CreateAccountResponse{
+		Account: convertAccount(acc),
+	}
+	return resp, nil
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	pbAccount := &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner.String,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		CreatedAt:        timestamppb.New(account.CreatedAt),
+		OverdraftLimit:   account.OverdraftLimit,
+		BalanceDecimal:   money.Money{Amount: account.Balance, Currency: account.Currency}.String(),
+		AvailableBalance: account.Balance - account.HeldAmount,
+		Status:           account.Status,
+		Type:             account.Type,
+		Nickname:         account.Nickname,
+	}
+	if account.ClosedAt.Valid {
+		pbAccount.ClosedAt = timestamppb.New(account.ClosedAt.Time)
+	}
+	return pbAccount
+}
+
+func validateCreateAccountRequest(req *pb.CreateAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := val.ValidateAccountType(req.GetType()); err != nil {
+		violations = append(violations, fieldViolation("type", err))
+	}
+
+	if err := val.ValidateAccountNickname(req.GetNickname()); err != nil {
+		violations = append(violations, fieldViolation("nickname", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,178 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"github.com/dibrito/simple-bank/worker"
+	"github.com/hibiken/asynq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxBatchItems is how many transfers a single batch may hold.
+const maxBatchItems = 1000
+
+func (server *Server) CreateBatchTransfer(ctx context.Context, req *pb.CreateBatchTransferRequest) (*pb.CreateBatchTransferResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateBatchTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	account, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	if account.Owner.String != payload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "from account does not belong to authenticated user")
+	}
+
+	// every row is checked before anything is stored,
+	// so a batch is either accepted whole or rejected with all its bad rows
+	violations, err = server.validateBatchDestinations(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	arg := db.CreateBatchTxParams{
+		Owner:         payload.Username,
+		FromAccountID: req.GetFromAccountId(),
+		Currency:      req.GetCurrency(),
+		AfterCreate: func(batch db.Batch) error {
+			tp := &worker.PayloadProcessBatch{
+				BatchID: batch.ID,
+			}
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.Queue(worker.QueueDefault),
+			}
+			return server.taskDistributer.DistributeTaskProcessBatch(ctx, tp, opts...)
+		},
+	}
+	for _, item := range req.GetItems() {
+		arg.Items = append(arg.Items, db.BatchItemParams{
+			ToAccountID: item.GetToAccountId(),
+			Amount:      item.GetAmount(),
+		})
+	}
+
+	result, err := server.store.CreateBatchTx(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to create batch:%s", err)
+	}
+
+	resp := &pb.CreateBatchTransferResponse{
+		Batch: convertBatch(result.Batch),
+	}
+	return resp, nil
+}
+
+// validateBatchDestinations reports every item paying an account that
+// doesn't exist, isn't active, holds another currency or is the source account.
+func (server *Server) validateBatchDestinations(ctx context.Context, req *pb.CreateBatchTransferRequest) (violations []*errdetails.BadRequest_FieldViolation, err error) {
+	// payroll files often pay the same account several times
+	accounts := map[int64]*db.Account{}
+	for i, item := range req.GetItems() {
+		field := fmt.Sprintf("items[%d].to_account_id", i)
+		id := item.GetToAccountId()
+		if id == req.GetFromAccountId() {
+			violations = append(violations, fieldViolation(field, fmt.Errorf("must not be the source account")))
+			continue
+		}
+
+		account, ok := accounts[id]
+		if !ok {
+			got, err := server.existingAccount(ctx, id)
+			if err != nil && status.Code(err) != codes.NotFound {
+				return nil, err
+			}
+			if err == nil {
+				account = &got
+			}
+			accounts[id] = account
+		}
+
+		if account == nil {
+			violations = append(violations, fieldViolation(field, fmt.Errorf("account [%d] not found", id)))
+			continue
+		}
+		if account.Status != db.AccountStatusActive {
+			violations = append(violations, fieldViolation(field, fmt.Errorf("account [%d] is %s", id, account.Status)))
+			continue
+		}
+		if account.Currency != req.GetCurrency() {
+			violations = append(violations, fieldViolation(field, fmt.Errorf("account [%d] currency mismatch: %s vs %s", id, account.Currency, req.GetCurrency())))
+		}
+	}
+	return violations, nil
+}
+
+func convertBatch(batch db.Batch) *pb.Batch {
+	pbBatch := &pb.Batch{
+		Id:             batch.ID,
+		Owner:          batch.Owner,
+		FromAccountId:  batch.FromAccountID,
+		Currency:       batch.Currency,
+		Status:         batch.Status,
+		ItemCount:      batch.ItemCount,
+		TotalAmount:    batch.TotalAmount,
+		SucceededCount: batch.SucceededCount,
+		FailedCount:    batch.FailedCount,
+		CreatedAt:      timestamppb.New(batch.CreatedAt),
+	}
+	if batch.CompletedAt.Valid {
+		pbBatch.CompletedAt = timestamppb.New(batch.CompletedAt.Time)
+	}
+	return pbBatch
+}
+
+func convertBatchItem(item db.BatchItem) *pb.BatchItem {
+	return &pb.BatchItem{
+		Id:            item.ID,
+		RowNumber:     item.RowNumber,
+		ToAccountId:   item.ToAccountID,
+		Amount:        item.Amount,
+		Status:        item.Status,
+		FailureReason: item.FailureReason,
+		TransferId:    item.TransferID.Int64,
+	}
+}
+
+func validateCreateBatchTransferRequest(req *pb.CreateBatchTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if n := len(req.GetItems()); n < 1 || n > maxBatchItems {
+		violations = append(violations, fieldViolation("items", fmt.Errorf("must hold from %d to %d items", 1, maxBatchItems)))
+	}
+
+	for i, item := range req.GetItems() {
+		if err := val.ValidateAccountId(item.GetToAccountId()); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("items[%d].to_account_id", i), err))
+		}
+
+		if err := val.ValidateAmount(item.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("items[%d].amount", i), err))
+		}
+	}
+
+	return violations
+}
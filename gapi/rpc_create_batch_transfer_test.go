@@ -0,0 +1,278 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/dibrito/simple-bank/worker"
+	mockwk "github.com/dibrito/simple-bank/worker/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateBatchTransferApi(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account3 := randomAccount(user2.Username)
+	account1.ID, account2.ID, account3.ID = 1, 2, 3
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+	account3.Currency = util.EUR
+
+	closed := randomAccount(user2.Username)
+	closed.ID, closed.Currency, closed.Status = 5, util.USD, db.AccountStatusClosed
+	system := randomAccount("")
+	system.ID, system.Currency, system.Type = 6, util.USD, db.AccountTypeSystem
+
+	newRequest := func() *pb.CreateBatchTransferRequest {
+		return &pb.CreateBatchTransferRequest{
+			FromAccountId: account1.ID,
+			Currency:      util.USD,
+			Items: []*pb.BatchTransferItem{
+				{ToAccountId: account2.ID, Amount: 10},
+				{ToAccountId: account2.ID, Amount: 20},
+			},
+		}
+	}
+
+	withUser1 := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, util.RoleCustomer, time.Minute)
+	}
+
+	tcs := []struct {
+		name          string
+		req           *pb.CreateBatchTransferRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateBatchTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  newRequest(),
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				// a destination paid twice is only fetched once
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				batch := db.Batch{
+					ID:            1,
+					Owner:         user1.Username,
+					FromAccountID: account1.ID,
+					Currency:      util.USD,
+					Status:        db.BatchStatusPending,
+					ItemCount:     2,
+					TotalAmount:   30,
+				}
+				store.EXPECT().
+					CreateBatchTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateBatchTxParams) (db.CreateBatchTxResult, error) {
+						require.Equal(t, user1.Username, arg.Owner)
+						require.Equal(t, []db.BatchItemParams{
+							{ToAccountID: account2.ID, Amount: 10},
+							{ToAccountID: account2.ID, Amount: 20},
+						}, arg.Items)
+						require.NoError(t, arg.AfterCreate(batch))
+						return db.CreateBatchTxResult{Batch: batch}, nil
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskProcessBatch(gomock.Any(), gomock.Eq(&worker.PayloadProcessBatch{BatchID: batch.ID}), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateBatchTransferResponse, err error) {
+				require.NoError(t, err)
+				batch := res.GetBatch()
+				require.Equal(t, db.BatchStatusPending, batch.GetStatus())
+				require.Equal(t, int32(2), batch.GetItemCount())
+				require.Equal(t, int64(30), batch.GetTotalAmount())
+				require.Nil(t, batch.GetCompletedAt())
+			},
+		},
+		{
+			name: "EnqueueFailed",
+			req:  newRequest(),
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					CreateBatchTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateBatchTxParams) (db.CreateBatchTxResult, error) {
+						err := arg.AfterCreate(db.Batch{ID: 1})
+						return db.CreateBatchTxResult{}, err
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskProcessBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(fmt.Errorf("redis is down"))
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateBatchTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+		{
+			name: "InvalidDestinations",
+			req: func() *pb.CreateBatchTransferRequest {
+				req := newRequest()
+				req.Items = append(req.Items,
+					&pb.BatchTransferItem{ToAccountId: account3.ID, Amount: 10},
+					&pb.BatchTransferItem{ToAccountId: 4, Amount: 10},
+					&pb.BatchTransferItem{ToAccountId: account1.ID, Amount: 10},
+					&pb.BatchTransferItem{ToAccountId: closed.ID, Amount: 10},
+					&pb.BatchTransferItem{ToAccountId: system.ID, Amount: 10},
+				)
+				return req
+			}(),
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(4))).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(closed.ID)).Times(1).Return(closed, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(system.ID)).Times(1).Return(system, nil)
+				store.EXPECT().CreateBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateBatchTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, []string{
+					"items[2].to_account_id",
+					"items[3].to_account_id",
+					"items[4].to_account_id",
+					"items[5].to_account_id",
+					"items[6].to_account_id",
+				}, violationFields(t, err))
+			},
+		},
+		{
+			name: "InvalidItems",
+			req: func() *pb.CreateBatchTransferRequest {
+				req := newRequest()
+				req.Items[0].Amount = 0
+				req.Items[1].ToAccountId = 0
+				return req
+			}(),
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateBatchTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, []string{
+					"items[0].amount",
+					"items[1].to_account_id",
+				}, violationFields(t, err))
+			},
+		},
+		{
+			name: "TooManyItems",
+			req: func() *pb.CreateBatchTransferRequest {
+				req := newRequest()
+				for len(req.Items) <= maxBatchItems {
+					req.Items = append(req.Items, &pb.BatchTransferItem{ToAccountId: account2.ID, Amount: 1})
+				}
+				return req
+			}(),
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateBatchTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, []string{"items"}, violationFields(t, err))
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req:  newRequest(),
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().CreateBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateBatchTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req:  newRequest(),
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreateBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateBatchTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// separate controllers, see TestCreateUserApi
+			ctrlStore := gomock.NewController(t)
+			defer ctrlStore.Finish()
+			store := mockdb.NewMockStore(ctrlStore)
+
+			ctrlTaskDistributor := gomock.NewController(t)
+			defer ctrlTaskDistributor.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrlTaskDistributor)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServer(t, store, taskDistributor)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_CreateBatchTransfer_FullMethodName)
+			res, err := server.CreateBatchTransfer(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+// violationFields returns the fields of the bad request violations in err.
+func violationFields(t *testing.T, err error) []string {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	var fields []string
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		require.True(t, ok)
+		for _, violation := range badRequest.GetFieldViolations() {
+			fields = append(fields, violation.GetField())
+		}
+	}
+	return fields
+}
@@ -0,0 +1,132 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateHold(ctx context.Context, req *pb.CreateHoldRequest) (*pb.CreateHoldResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateHoldRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	account, err := server.validAccount(ctx, req.GetAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	if account.Owner.String != payload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account does not belong to authenticated user")
+	}
+
+	// captures are posted without conversion
+	_, err = server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.HoldTx(ctx, db.HoldTxParams{
+		AccountID:   req.GetAccountId(),
+		ToAccountID: req.GetToAccountId(),
+		Amount:      req.GetAmount(),
+		ExpiresAt:   time.Now().Add(server.config.HoldDuration),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) ||
+			errors.Is(err, db.ErrAccountFrozen) ||
+			errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "fail to create hold:%s", err)
+	}
+
+	resp := &pb.CreateHoldResponse{
+		Hold:    convertHold(result.Hold),
+		Account: convertAccount(result.Account),
+	}
+	return resp, nil
+}
+
+func convertHold(hold db.Hold) *pb.Hold {
+	return &pb.Hold{
+		Id:          hold.ID,
+		AccountId:   hold.AccountID,
+		ToAccountId: hold.ToAccountID,
+		Amount:      hold.Amount,
+		Status:      hold.Status,
+		TransferId:  hold.TransferID.Int64,
+		CreatedAt:   timestamppb.New(hold.CreatedAt),
+		ExpiresAt:   timestamppb.New(hold.ExpiresAt),
+	}
+}
+
+// ownedHold returns a hold on one of the user's accounts, with that account.
+func (server *Server) ownedHold(ctx context.Context, username string, id int64) (db.Hold, db.Account, error) {
+	hold, err := server.store.GetHold(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return hold, db.Account{}, status.Errorf(codes.NotFound, "hold not found")
+		}
+		return hold, db.Account{}, status.Errorf(codes.Internal, "fail to get hold:%s", err)
+	}
+
+	account, err := server.existingAccount(ctx, hold.AccountID)
+	if err != nil {
+		return hold, account, err
+	}
+	if account.Owner.String != username {
+		return hold, account, status.Errorf(codes.PermissionDenied, "hold does not belong to authenticated user")
+	}
+	return hold, account, nil
+}
+
+// holdError maps the errors of capturing or voiding a hold to a status.
+func holdError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrHoldNotPending), errors.Is(err, db.ErrHoldExpired),
+		errors.Is(err, db.ErrInsufficientFunds),
+		errors.Is(err, db.ErrAccountFrozen), errors.Is(err, db.ErrAccountClosed):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	case errors.Is(err, db.ErrCaptureExceedsHold):
+		return status.Errorf(codes.InvalidArgument, "%s", err)
+	case errors.Is(err, db.ErrSingleTransferLimit), errors.Is(err, db.ErrDailyTransferLimit):
+		return status.Errorf(codes.ResourceExhausted, "%s", err)
+	default:
+		return status.Errorf(codes.Internal, "fail to update hold:%s", err)
+	}
+}
+
+func validateCreateHoldRequest(req *pb.CreateHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateAccountId(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,200 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateHoldApi(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account3 := randomAccount(user2.Username)
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+	account3.Currency = util.EUR
+	amount := int64(10)
+
+	newRequest := func() *pb.CreateHoldRequest {
+		return &pb.CreateHoldRequest{
+			AccountId:   account1.ID,
+			ToAccountId: account2.ID,
+			Amount:      amount,
+			Currency:    util.USD,
+		}
+	}
+
+	withUser1 := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, util.RoleCustomer, time.Minute)
+	}
+
+	tcs := []struct {
+		name          string
+		req           *pb.CreateHoldRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateHoldResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  newRequest(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+
+				held := account1
+				held.HeldAmount = amount
+				store.EXPECT().
+					HoldTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.HoldTxParams) (db.HoldTxResult, error) {
+						require.Equal(t, account1.ID, arg.AccountID)
+						require.Equal(t, account2.ID, arg.ToAccountID)
+						require.Equal(t, amount, arg.Amount)
+						require.WithinDuration(t, time.Now().Add(time.Hour), arg.ExpiresAt, time.Second)
+						return db.HoldTxResult{
+							Hold: db.Hold{
+								ID:          1,
+								AccountID:   arg.AccountID,
+								ToAccountID: arg.ToAccountID,
+								Amount:      arg.Amount,
+								Status:      db.HoldStatusPending,
+								ExpiresAt:   arg.ExpiresAt,
+							},
+							Account: held,
+						}, nil
+					})
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateHoldResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.HoldStatusPending, res.GetHold().GetStatus())
+				require.Equal(t, amount, res.GetHold().GetAmount())
+				require.Equal(t, account1.Balance, res.GetAccount().GetBalance())
+				require.Equal(t, account1.Balance-amount, res.GetAccount().GetAvailableBalance())
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			req:  newRequest(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					HoldTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.HoldTxResult{}, db.ErrInsufficientFunds)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req:  newRequest(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().HoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "ToAccountCurrencyMismatch",
+			req: &pb.CreateHoldRequest{
+				AccountId:   account1.ID,
+				ToAccountId: account3.ID,
+				Amount:      amount,
+				Currency:    util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+				store.EXPECT().HoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "InvalidAmount",
+			req: &pb.CreateHoldRequest{
+				AccountId:   account1.ID,
+				ToAccountId: account2.ID,
+				Amount:      0,
+				Currency:    util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().HoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "Unauthenticated",
+			req:  newRequest(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().HoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateHoldResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_CreateHold_FullMethodName)
+			res, err := server.CreateHold(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
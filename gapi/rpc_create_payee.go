@@ -0,0 +1,116 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreatePayee(ctx context.Context, req *pb.CreatePayeeRequest) (*pb.CreatePayeeResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreatePayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	arg := db.CreatePayeeParams{
+		Owner:    payload.Username,
+		Nickname: req.GetNickname(),
+		Currency: req.GetCurrency(),
+	}
+	if req.GetAccountId() != 0 {
+		_, err = server.validAccount(ctx, req.GetAccountId(), req.GetCurrency())
+		arg.AccountID = sql.NullInt64{Int64: req.GetAccountId(), Valid: true}
+	} else {
+		_, _, err = server.resolvePayee(ctx, req.GetUsername(), "", req.GetCurrency())
+		arg.Username = sql.NullString{String: req.GetUsername(), Valid: true}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	payee, err := server.store.CreatePayee(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "payee nickname already exists:%s", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "fail to create payee:%s", err)
+	}
+
+	resp := &pb.CreatePayeeResponse{
+		Payee: server.convertPayee(payee),
+	}
+	return resp, nil
+}
+
+func (server *Server) convertPayee(payee db.Payee) *pb.Payee {
+	return &pb.Payee{
+		Id:               payee.ID,
+		Nickname:         payee.Nickname,
+		AccountId:        payee.AccountID.Int64,
+		Username:         payee.Username.String,
+		Currency:         payee.Currency,
+		CreatedAt:        timestamppb.New(payee.CreatedAt),
+		CoolingOffEndsAt: timestamppb.New(server.coolingOffEnd(payee)),
+	}
+}
+
+// coolingOffEnd is when transfers to payee stop being capped.
+func (server *Server) coolingOffEnd(payee db.Payee) time.Time {
+	return payee.CreatedAt.Add(server.config.PayeeCoolingOffPeriod)
+}
+
+// ownedPayee returns a payee saved by the user.
+func (server *Server) ownedPayee(ctx context.Context, username string, id int64) (db.Payee, error) {
+	payee, err := server.store.GetPayee(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return payee, status.Errorf(codes.NotFound, "payee not found")
+		}
+		return payee, status.Errorf(codes.Internal, "fail to get payee:%s", err)
+	}
+
+	if payee.Owner != username {
+		return payee, status.Errorf(codes.PermissionDenied, "payee does not belong to authenticated user")
+	}
+	return payee, nil
+}
+
+func validateCreatePayeeRequest(req *pb.CreatePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateNickname(req.GetNickname()); err != nil {
+		violations = append(violations, fieldViolation("nickname", err))
+	}
+
+	if (req.GetAccountId() == 0) == (req.GetUsername() == "") {
+		violations = append(violations, fieldViolation("account_id", errors.New("exactly one of account_id or username must be set")))
+	} else if req.GetAccountId() != 0 {
+		if err := val.ValidateAccountId(req.GetAccountId()); err != nil {
+			violations = append(violations, fieldViolation("account_id", err))
+		}
+	} else if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,203 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomPayee(owner string, account db.Account) db.Payee {
+	return db.Payee{
+		ID:        util.RandomInt(1, 1000),
+		Owner:     owner,
+		Nickname:  util.RandomOwner(),
+		AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+		Currency:  account.Currency,
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+	}
+}
+
+func TestCreatePayeeApi(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account2 := randomAccount(user2.Username)
+	account2.Currency = util.USD
+
+	withUser1 := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, util.RoleCustomer, time.Minute)
+	}
+
+	tcs := []struct {
+		name          string
+		req           *pb.CreatePayeeRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreatePayeeResponse, err error)
+	}{
+		{
+			name: "ByAccount",
+			req: &pb.CreatePayeeRequest{
+				Nickname:  "landlord",
+				AccountId: account2.ID,
+				Currency:  util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+
+				arg := db.CreatePayeeParams{
+					Owner:     user1.Username,
+					Nickname:  "landlord",
+					AccountID: sql.NullInt64{Int64: account2.ID, Valid: true},
+					Currency:  util.USD,
+				}
+				store.EXPECT().
+					CreatePayee(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Payee{ID: 1, Owner: arg.Owner, Nickname: arg.Nickname, AccountID: arg.AccountID, Currency: arg.Currency, CreatedAt: time.Now()}, nil)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				require.NoError(t, err)
+				payee := res.GetPayee()
+				require.Equal(t, account2.ID, payee.GetAccountId())
+				require.Empty(t, payee.GetUsername())
+				require.WithinDuration(t, time.Now().Add(24*time.Hour), payee.GetCoolingOffEndsAt().AsTime(), time.Second)
+			},
+		},
+		{
+			name: "ByUsername",
+			req: &pb.CreatePayeeRequest{
+				Nickname: "mum",
+				Username: user2.Username,
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user2.Username)).Times(1).Return(user2, nil)
+				store.EXPECT().GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Any()).Times(1).Return(account2, nil)
+
+				arg := db.CreatePayeeParams{
+					Owner:    user1.Username,
+					Nickname: "mum",
+					Username: sql.NullString{String: user2.Username, Valid: true},
+					Currency: util.USD,
+				}
+				store.EXPECT().
+					CreatePayee(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Payee{ID: 1, Owner: arg.Owner, Nickname: arg.Nickname, Username: arg.Username, Currency: arg.Currency}, nil)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user2.Username, res.GetPayee().GetUsername())
+				require.Zero(t, res.GetPayee().GetAccountId())
+			},
+		},
+		{
+			name: "AccountCurrencyMismatch",
+			req: &pb.CreatePayeeRequest{
+				Nickname:  "landlord",
+				AccountId: account2.ID,
+				Currency:  util.EUR,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "NicknameTaken",
+			req: &pb.CreatePayeeRequest{
+				Nickname:  "landlord",
+				AccountId: account2.ID,
+				Currency:  util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(1).Return(db.Payee{}, &pq.Error{Code: "23505"})
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.AlreadyExists, st.Code())
+			},
+		},
+		{
+			name: "AccountAndUsername",
+			req: &pb.CreatePayeeRequest{
+				Nickname:  "landlord",
+				AccountId: account2.ID,
+				Username:  user2.Username,
+				Currency:  util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "Unauthenticated",
+			req: &pb.CreatePayeeRequest{
+				Nickname:  "landlord",
+				AccountId: account2.ID,
+				Currency:  util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_CreatePayee_FullMethodName)
+			res, err := server.CreatePayee(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
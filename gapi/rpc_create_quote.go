@@ -0,0 +1,112 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fx"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateQuote(ctx context.Context, req *pb.CreateQuoteRequest) (*pb.CreateQuoteResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateQuoteRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	fromAccount, err := server.existingAccount(ctx, req.GetFromAccountId())
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Owner.String != payload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "from account does not belong to authenticated user")
+	}
+
+	toAccount, err := server.existingAccount(ctx, req.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Currency == toAccount.Currency {
+		return nil, status.Errorf(codes.InvalidArgument, "accounts share currency %s, no quote is needed", fromAccount.Currency)
+	}
+
+	rate, err := server.rates.Rate(ctx, fromAccount.Currency, toAccount.Currency)
+	if err != nil {
+		if errors.Is(err, fx.ErrRateNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "fail to get exchange rate:%s", err)
+	}
+	offered := fx.ApplySpread(rate, server.spread)
+	toAmount, err := convertAmount(req.GetAmount(), offered, fromAccount.Currency, toAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := server.store.CreateFxQuote(ctx, db.CreateFxQuoteParams{
+		ID:            uuid.New(),
+		Username:      payload.Username,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		FromCurrency:  fromAccount.Currency,
+		ToCurrency:    toAccount.Currency,
+		Amount:        req.GetAmount(),
+		ToAmount:      toAmount,
+		Rate:          fx.FormatRate(offered),
+		Spread:        fx.FormatRate(server.spread),
+		ExpiresAt:     time.Now().Add(server.config.FXQuoteDuration),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to create quote:%s", err)
+	}
+
+	resp := &pb.CreateQuoteResponse{
+		Quote: convertQuote(quote),
+	}
+	return resp, nil
+}
+
+func convertQuote(quote db.FxQuote) *pb.Quote {
+	return &pb.Quote{
+		Id:            quote.ID.String(),
+		FromAccountId: quote.FromAccountID,
+		ToAccountId:   quote.ToAccountID,
+		FromCurrency:  quote.FromCurrency,
+		ToCurrency:    quote.ToCurrency,
+		Amount:        quote.Amount,
+		ToAmount:      quote.ToAmount,
+		Rate:          quote.Rate,
+		Spread:        quote.Spread,
+		ExpiresAt:     timestamppb.New(quote.ExpiresAt),
+		CreatedAt:     timestamppb.New(quote.CreatedAt),
+	}
+}
+
+func validateCreateQuoteRequest(req *pb.CreateQuoteRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateAccountId(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,162 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateQuoteApi(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	usdAccount := randomAccount(user1.Username)
+	eurAccount := randomAccount(user2.Username)
+	brlAccount := randomAccount(user2.Username)
+	usdAccount2 := randomAccount(user2.Username)
+	usdAccount.Currency = util.USD
+	eurAccount.Currency = util.EUR
+	brlAccount.Currency = util.BRL
+	usdAccount2.Currency = util.USD
+
+	withUser1 := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, util.RoleCustomer, time.Minute)
+	}
+
+	tcs := []struct {
+		name          string
+		req           *pb.CreateQuoteRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateQuoteResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.CreateQuoteRequest{FromAccountId: eurAccount.ID, ToAccountId: usdAccount.ID, Amount: 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(eurAccount.ID)).Times(1).Return(eurAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(usdAccount.ID)).Times(1).Return(usdAccount, nil)
+				store.EXPECT().
+					CreateFxQuote(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateFxQuoteParams) (db.FxQuote, error) {
+						// 1.08 with 1% spread
+						require.Equal(t, "1.0692", arg.Rate)
+						require.Equal(t, "0.01", arg.Spread)
+						require.Equal(t, int64(107), arg.ToAmount)
+						require.Equal(t, user2.Username, arg.Username)
+						require.WithinDuration(t, time.Now().Add(time.Minute), arg.ExpiresAt, time.Second)
+						return db.FxQuote{
+							ID:            arg.ID,
+							FromAccountID: arg.FromAccountID,
+							ToAccountID:   arg.ToAccountID,
+							Amount:        arg.Amount,
+							ToAmount:      arg.ToAmount,
+							Rate:          arg.Rate,
+							Spread:        arg.Spread,
+							ExpiresAt:     arg.ExpiresAt,
+						}, nil
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateQuoteResponse, err error) {
+				require.NoError(t, err)
+				quote := res.GetQuote()
+				require.NotEmpty(t, quote.GetId())
+				require.Equal(t, int64(100), quote.GetAmount())
+				require.Equal(t, int64(107), quote.GetToAmount())
+				require.Equal(t, "1.0692", quote.GetRate())
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req:  &pb.CreateQuoteRequest{FromAccountId: eurAccount.ID, ToAccountId: usdAccount.ID, Amount: 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(eurAccount.ID)).Times(1).Return(eurAccount, nil)
+				store.EXPECT().CreateFxQuote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateQuoteResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "SameCurrency",
+			req:  &pb.CreateQuoteRequest{FromAccountId: usdAccount.ID, ToAccountId: usdAccount2.ID, Amount: 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(usdAccount.ID)).Times(1).Return(usdAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(usdAccount2.ID)).Times(1).Return(usdAccount2, nil)
+				store.EXPECT().CreateFxQuote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateQuoteResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "RateNotFound",
+			req:  &pb.CreateQuoteRequest{FromAccountId: usdAccount.ID, ToAccountId: brlAccount.ID, Amount: 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(usdAccount.ID)).Times(1).Return(usdAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(brlAccount.ID)).Times(1).Return(brlAccount, nil)
+				store.EXPECT().CreateFxQuote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateQuoteResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "InvalidAmount",
+			req:  &pb.CreateQuoteRequest{FromAccountId: usdAccount.ID, ToAccountId: eurAccount.ID, Amount: 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateFxQuote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.CreateQuoteResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_CreateQuote_FullMethodName)
+			res, err := server.CreateQuote(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
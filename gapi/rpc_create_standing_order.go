@@ -0,0 +1,148 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateStandingOrder(ctx context.Context, req *pb.CreateStandingOrderRequest) (*pb.CreateStandingOrderResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateStandingOrderRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	account, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	if account.Owner.String != payload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "from account does not belong to authenticated user")
+	}
+
+	// runs are posted without conversion
+	_, err = server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if req.StartAt != nil && req.GetStartAt().AsTime().After(start) {
+		start = req.GetStartAt().AsTime()
+	}
+	nextRunAt, err := util.NextRun(req.GetSchedule(), start)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+	var endAt sql.NullTime
+	if req.EndAt != nil {
+		endAt = sql.NullTime{Time: req.GetEndAt().AsTime(), Valid: true}
+		if nextRunAt.After(endAt.Time) {
+			return nil, status.Errorf(codes.InvalidArgument, "end_at is before the first run at %s", nextRunAt.Format(time.RFC3339))
+		}
+	}
+
+	order, err := server.store.CreateStandingOrder(ctx, db.CreateStandingOrderParams{
+		Owner:         payload.Username,
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+		Currency:      req.GetCurrency(),
+		Schedule:      req.GetSchedule(),
+		NextRunAt:     nextRunAt,
+		EndAt:         endAt,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to create standing order:%s", err)
+	}
+
+	resp := &pb.CreateStandingOrderResponse{
+		StandingOrder: convertStandingOrder(order),
+	}
+	return resp, nil
+}
+
+func convertStandingOrder(order db.StandingOrder) *pb.StandingOrder {
+	pbOrder := &pb.StandingOrder{
+		Id:            order.ID,
+		Owner:         order.Owner,
+		FromAccountId: order.FromAccountID,
+		ToAccountId:   order.ToAccountID,
+		Amount:        order.Amount,
+		Currency:      order.Currency,
+		Schedule:      order.Schedule,
+		NextRunAt:     timestamppb.New(order.NextRunAt),
+		Status:        order.Status,
+		CreatedAt:     timestamppb.New(order.CreatedAt),
+	}
+	if order.EndAt.Valid {
+		pbOrder.EndAt = timestamppb.New(order.EndAt.Time)
+	}
+	return pbOrder
+}
+
+func convertStandingOrderExecution(execution db.StandingOrderExecution) *pb.StandingOrderExecution {
+	return &pb.StandingOrderExecution{
+		Id:              execution.ID,
+		StandingOrderId: execution.StandingOrderID,
+		TransferId:      execution.TransferID.Int64,
+		Status:          execution.Status,
+		FailureReason:   execution.FailureReason,
+		RunAt:           timestamppb.New(execution.RunAt),
+		CreatedAt:       timestamppb.New(execution.CreatedAt),
+	}
+}
+
+// ownedStandingOrder returns a standing order of the user.
+func (server *Server) ownedStandingOrder(ctx context.Context, username string, id int64) (db.StandingOrder, error) {
+	order, err := server.store.GetStandingOrder(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return order, status.Errorf(codes.NotFound, "standing order not found")
+		}
+		return order, status.Errorf(codes.Internal, "fail to get standing order:%s", err)
+	}
+
+	if order.Owner != username {
+		return order, status.Errorf(codes.PermissionDenied, "standing order does not belong to authenticated user")
+	}
+	return order, nil
+}
+
+func validateCreateStandingOrderRequest(req *pb.CreateStandingOrderRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateAccountId(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := val.ValidateSchedule(req.GetSchedule()); err != nil {
+		violations = append(violations, fieldViolation("schedule", err))
+	}
+
+	return violations
+}
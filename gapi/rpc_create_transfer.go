@@ -0,0 +1,327 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/dibrito/simple-bank/currency"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fee"
+	"github.com/dibrito/simple-bank/fx"
+	"github.com/dibrito/simple-bank/money"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/val"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	violations := validateCreateTransferRequest(req, mtdt)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	arg, _, err := server.prepareTransfer(ctx, payload, req)
+	if err != nil {
+		return nil, err
+	}
+	arg.IdempotencyKey = mtdt.IdempotencyKey
+
+	result, err := server.store.TransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) ||
+			errors.Is(err, db.ErrAccountFrozen) ||
+			errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if errors.Is(err, db.ErrSingleTransferLimit) || errors.Is(err, db.ErrDailyTransferLimit) {
+			return nil, status.Errorf(codes.ResourceExhausted, "%s", err)
+		}
+		if errors.Is(err, db.ErrQuoteUnavailable) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if errors.Is(err, db.ErrIdempotencyKeyReused) {
+			return nil, status.Errorf(codes.AlreadyExists, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "fail to create transfer:%s", err)
+	}
+
+	resp := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+	return resp, nil
+}
+
+// prepareTransfer checks the accounts of req and works out the amounts and
+// fee of the transfer, as TransferTx expects them. It also returns the
+// destination account, which req may give indirectly.
+func (server *Server) prepareTransfer(ctx context.Context, payload *token.Payload, req *pb.CreateTransferRequest) (db.TransferTxParams, db.Account, error) {
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return db.TransferTxParams{}, db.Account{}, err
+	}
+	if fromAccount.Owner.String != payload.Username {
+		return db.TransferTxParams{}, db.Account{}, status.Errorf(codes.PermissionDenied, "from account does not belong to authenticated user")
+	}
+
+	amount, err := transferAmount(req)
+	if err != nil {
+		return db.TransferTxParams{}, db.Account{}, status.Errorf(codes.InvalidArgument, "invalid amount:%s", err)
+	}
+
+	// the destination may hold another currency, the amount is converted
+	toAccount, err := server.transferDestination(ctx, payload.Username, req, amount)
+	if err != nil {
+		return db.TransferTxParams{}, db.Account{}, err
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID:     req.GetFromAccountId(),
+		ToAccountID:       toAccount.ID,
+		Amount:            amount,
+		Currency:          req.GetCurrency(),
+		Username:          payload.Username,
+		Description:       req.GetDescription(),
+		ExternalReference: req.GetExternalReference(),
+		Tags:              req.GetTags(),
+	}
+	if req.GetQuoteId() != "" {
+		// the quote carries the rate and is checked when consumed by TransferTx
+		arg.QuoteID, _ = uuid.Parse(req.GetQuoteId())
+	} else {
+		rate, err := server.rates.Rate(ctx, fromAccount.Currency, toAccount.Currency)
+		if err != nil {
+			if errors.Is(err, fx.ErrRateNotFound) {
+				return arg, toAccount, status.Errorf(codes.FailedPrecondition, "%s", err)
+			}
+			return arg, toAccount, status.Errorf(codes.Internal, "fail to get exchange rate:%s", err)
+		}
+		arg.ToAmount, err = convertAmount(amount, rate, fromAccount.Currency, toAccount.Currency)
+		if err != nil {
+			return arg, toAccount, err
+		}
+		arg.ExchangeRate = fx.FormatRate(rate)
+	}
+
+	arg.Fee, err = fee.ForTransfer(ctx, server.store, fromAccount.Currency, toAccount.Currency, amount)
+	if err != nil {
+		return arg, toAccount, status.Errorf(codes.Internal, "fail to compute fee:%s", err)
+	}
+
+	return arg, toAccount, nil
+}
+
+// transferDestination returns the account credited by req, given by id,
+// saved payee, or resolved from the recipient username or email. However the
+// account is given, amount is checked against the cooling-off of the newest
+// payee saved for it.
+func (server *Server) transferDestination(ctx context.Context, username string, req *pb.CreateTransferRequest, amount int64) (db.Account, error) {
+	var account db.Account
+	var err error
+	switch {
+	case req.GetToAccountId() != 0:
+		account, err = server.existingAccount(ctx, req.GetToAccountId())
+	case req.GetPayeeId() != 0:
+		payee, err := server.ownedPayee(ctx, username, req.GetPayeeId())
+		if err != nil {
+			return db.Account{}, err
+		}
+		if err := server.checkCoolingOff(payee, amount); err != nil {
+			return db.Account{}, err
+		}
+		if payee.AccountID.Valid {
+			return server.existingAccount(ctx, payee.AccountID.Int64)
+		}
+		_, account, err := server.resolvePayee(ctx, payee.Username.String, "", payee.Currency)
+		return account, err
+	default:
+		_, account, err = server.resolvePayee(ctx, req.GetToUsername(), req.GetToEmail(), req.GetCurrency())
+	}
+	if err != nil || amount <= server.config.PayeeCoolingOffLimit {
+		return account, err
+	}
+
+	// paying a new payee without naming it is held to the same limit
+	payee, err := server.store.GetNewestPayeeOfAccount(ctx, db.GetNewestPayeeOfAccountParams{
+		Owner:        username,
+		AccountID:    sql.NullInt64{Int64: account.ID, Valid: true},
+		AccountOwner: account.Owner.String,
+		Currency:     account.Currency,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, nil
+		}
+		return account, status.Errorf(codes.Internal, "fail to get payee:%s", err)
+	}
+	return account, server.checkCoolingOff(payee, amount)
+}
+
+// checkCoolingOff returns a gRPC status error if amount is above what may be
+// sent to payee during its cooling-off period.
+func (server *Server) checkCoolingOff(payee db.Payee, amount int64) error {
+	if end := server.coolingOffEnd(payee); amount > server.config.PayeeCoolingOffLimit && time.Now().Before(end) {
+		return status.Errorf(codes.FailedPrecondition,
+			"payee is in its cooling-off period until %s, transfers above %d are blocked",
+			end.Format(time.RFC3339), server.config.PayeeCoolingOffLimit)
+	}
+	return nil
+}
+
+// validAccount returns a gRPC status error if the account does not exist
+// or does not hold the given currency.
+func (server *Server) validAccount(ctx context.Context, accountID int64, code string) (db.Account, error) {
+	account, err := server.existingAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Currency != code {
+		return account, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, code)
+	}
+
+	return account, nil
+}
+
+func (server *Server) existingAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "fail to get account:%s", err)
+	}
+
+	// system accounts only move money through the bank's own transactions
+	if account.Type == db.AccountTypeSystem {
+		return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+	}
+
+	return account, nil
+}
+
+// convertAmount converts amount minor units of from into minor units of to,
+// taking each currency's number of decimal places into account.
+func convertAmount(amount int64, rate *big.Rat, from, to string) (int64, error) {
+	fromCurrency, ok := currency.Get(from)
+	if !ok {
+		return 0, status.Errorf(codes.Internal, "unknown currency %s", from)
+	}
+	toCurrency, ok := currency.Get(to)
+	if !ok {
+		return 0, status.Errorf(codes.Internal, "unknown currency %s", to)
+	}
+
+	toAmount := fx.ConvertMinor(amount, rate, fromCurrency.MinorUnit, toCurrency.MinorUnit)
+	if toAmount <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "amount is too small to convert to %s", to)
+	}
+	return toAmount, nil
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:                transfer.ID,
+		FromAccountId:     transfer.FromAccountID,
+		ToAccountId:       transfer.ToAccountID,
+		Amount:            transfer.Amount,
+		CreatedAt:         timestamppb.New(transfer.CreatedAt),
+		ToAmount:          transfer.ToAmount,
+		ExchangeRate:      transfer.ExchangeRate,
+		ReversalOfId:      transfer.ReversalOfID.Int64,
+		ReversedAmount:    transfer.ReversedAmount,
+		Description:       transfer.Description,
+		ExternalReference: transfer.ExternalReference,
+		Tags:              transfer.Tags,
+		Fee:               transfer.Fee,
+	}
+}
+
+func convertEntry(entry db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:         entry.ID,
+		AccountId:  entry.AccountID,
+		Amount:     entry.Amount,
+		CreatedAt:  timestamppb.New(entry.CreatedAt),
+		TransferId: entry.TransferID.Int64,
+	}
+}
+
+// transferAmount returns the amount in minor units, parsing amount_decimal
+// with the decimal places of the request currency when it is set.
+func transferAmount(req *pb.CreateTransferRequest) (int64, error) {
+	if req.GetAmountDecimal() == "" {
+		return req.GetAmount(), nil
+	}
+	m, err := money.Parse(req.GetAmountDecimal(), req.GetCurrency())
+	if err != nil {
+		return 0, err
+	}
+	if err := val.ValidateAmount(m.Amount); err != nil {
+		return 0, err
+	}
+	return m.Amount, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest, mtdt *Metadata) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	violations = append(violations, validatePayee(req.GetToAccountId(), req.GetToUsername(), req.GetToEmail(), req.GetPayeeId())...)
+
+	if req.GetAmountDecimal() != "" {
+		if req.GetAmount() != 0 {
+			violations = append(violations, fieldViolation("amount", errors.New("must not be set together with amount_decimal")))
+		} else if _, err := transferAmount(req); err != nil {
+			violations = append(violations, fieldViolation("amount_decimal", err))
+		}
+	} else if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.GetQuoteId() != "" {
+		if _, err := uuid.Parse(req.GetQuoteId()); err != nil {
+			violations = append(violations, fieldViolation("quote_id", errors.New("must be a valid uuid")))
+		}
+	}
+
+	if err := val.ValidateDescription(req.GetDescription()); err != nil {
+		violations = append(violations, fieldViolation("description", err))
+	}
+
+	if err := val.ValidateExternalReference(req.GetExternalReference()); err != nil {
+		violations = append(violations, fieldViolation("external_reference", err))
+	}
+
+	if err := val.ValidateTags(req.GetTags()); err != nil {
+		violations = append(violations, fieldViolation("tags", err))
+	}
+
+	if err := val.ValidateIdempotencyKey(mtdt.IdempotencyKey); err != nil {
+		violations = append(violations, fieldViolation(idempotencyKeyHeader, err))
+	}
+
+	return violations
+}
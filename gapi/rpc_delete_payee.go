@@ -0,0 +1,43 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeletePayee(ctx context.Context, req *pb.DeletePayeeRequest) (*pb.DeletePayeeResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeletePayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	payee, err := server.ownedPayee(ctx, payload.Username, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.store.DeletePayee(ctx, payee.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to delete payee:%s", err)
+	}
+
+	return &pb.DeletePayeeResponse{}, nil
+}
+
+func validateDeletePayeeRequest(req *pb.DeletePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	acc, err := server.getOwnedAccount(ctx, payload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetAccountResponse{
+		Account: convertAccount(acc),
+	}
+	return resp, nil
+}
+
+// getOwnedAccount returns the account if it belongs to the authenticated user,
+// or a gRPC status error otherwise.
+func (server *Server) getOwnedAccount(ctx context.Context, payload *token.Payload, id int64) (db.Account, error) {
+	acc, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return acc, status.Errorf(codes.NotFound, "account not found")
+		}
+		return acc, status.Errorf(codes.Internal, "fail to get account:%s", err)
+	}
+
+	if acc.Owner.String != payload.Username {
+		return acc, status.Errorf(codes.PermissionDenied, "account does not belong to authenticated user")
+	}
+
+	return acc, nil
+}
+
+func validateGetAccountRequest(req *pb.GetAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetBatch(ctx context.Context, req *pb.GetBatchRequest) (*pb.GetBatchResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetBatchRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	batch, err := server.store.GetBatch(ctx, req.GetId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "batch not found")
+		}
+		return nil, status.Errorf(codes.Internal, "fail to get batch:%s", err)
+	}
+	if batch.Owner != payload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "batch does not belong to authenticated user")
+	}
+
+	items, err := server.store.ListBatchItems(ctx, db.ListBatchItemsParams{
+		BatchID: batch.ID,
+		Offset:  (req.GetPageId() - 1) * req.GetPageSize(),
+		Limit:   req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to list batch items:%s", err)
+	}
+
+	resp := &pb.GetBatchResponse{
+		Batch: convertBatch(batch),
+	}
+	for _, item := range items {
+		resp.Items = append(resp.Items, convertBatchItem(item))
+	}
+	return resp, nil
+}
+
+func validateGetBatchRequest(req *pb.GetBatchRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
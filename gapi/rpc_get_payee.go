@@ -0,0 +1,39 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) GetPayee(ctx context.Context, req *pb.GetPayeeRequest) (*pb.GetPayeeResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetPayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	payee, err := server.ownedPayee(ctx, payload.Username, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetPayeeResponse{
+		Payee: server.convertPayee(payee),
+	}
+	return resp, nil
+}
+
+func validateGetPayeeRequest(req *pb.GetPayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
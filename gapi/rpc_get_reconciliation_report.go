@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) GetReconciliationReport(ctx context.Context, req *pb.GetReconciliationReportRequest) (*pb.GetReconciliationReportResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	report, err := server.store.GetLatestReconciliationReport(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "no reconciliation has run yet")
+		}
+		return nil, status.Errorf(codes.Internal, "fail to get reconciliation report:%s", err)
+	}
+
+	rsp, err := convertReconciliationReport(report)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to read reconciliation report:%s", err)
+	}
+
+	return &pb.GetReconciliationReportResponse{Report: rsp}, nil
+}
+
+func convertReconciliationReport(report db.ReconciliationReport) (*pb.ReconciliationReport, error) {
+	var discrepancies []db.Discrepancy
+	if err := json.Unmarshal(report.Discrepancies, &discrepancies); err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.ReconciliationReport{
+		Id:               report.ID,
+		AccountsChecked:  report.AccountsChecked,
+		TransfersChecked: report.TransfersChecked,
+		CreatedAt:        timestamppb.New(report.CreatedAt),
+	}
+	for _, d := range discrepancies {
+		rsp.Discrepancies = append(rsp.Discrepancies, &pb.Discrepancy{
+			Kind:       d.Kind,
+			AccountId:  d.AccountID,
+			TransferId: d.TransferID,
+			Expected:   d.Expected,
+			Actual:     d.Actual,
+		})
+	}
+	return rsp, nil
+}
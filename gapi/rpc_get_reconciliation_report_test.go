@@ -0,0 +1,123 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetReconciliationReportApi(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	auditor, _ := randomUser(t)
+
+	discrepancies, err := json.Marshal([]db.Discrepancy{
+		{Kind: db.DiscrepancyBalance, AccountID: 7, Expected: 100, Actual: 90},
+	})
+	require.NoError(t, err)
+	report := db.ReconciliationReport{
+		ID:               1,
+		AccountsChecked:  10,
+		TransfersChecked: 20,
+		DiscrepancyCount: 1,
+		Discrepancies:    discrepancies,
+		CreatedAt:        time.Now(),
+	}
+
+	withUser := func(username, role string) func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return func(t *testing.T, tokenMaker token.Maker) context.Context {
+			return newContextWithBearerToken(t, tokenMaker, username, role, time.Minute)
+		}
+	}
+
+	tcs := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.GetReconciliationReportResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestReconciliationReport(gomock.Any()).Times(1).Return(report, nil)
+			},
+			buildContext: withUser(admin.Username, util.RoleAdmin),
+			checkResponse: func(t *testing.T, res *pb.GetReconciliationReportResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, report.ID, res.GetReport().GetId())
+				require.Equal(t, report.AccountsChecked, res.GetReport().GetAccountsChecked())
+				require.Len(t, res.GetReport().GetDiscrepancies(), 1)
+				d := res.GetReport().GetDiscrepancies()[0]
+				require.Equal(t, db.DiscrepancyBalance, d.GetKind())
+				require.Equal(t, int64(7), d.GetAccountId())
+				require.Equal(t, int64(100), d.GetExpected())
+				require.Equal(t, int64(90), d.GetActual())
+			},
+		},
+		{
+			name: "Auditor",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestReconciliationReport(gomock.Any()).Times(1).Return(report, nil)
+			},
+			buildContext: withUser(auditor.Username, util.RoleAuditor),
+			checkResponse: func(t *testing.T, res *pb.GetReconciliationReportResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, report.ID, res.GetReport().GetId())
+			},
+		},
+		{
+			name: "NotAdmin",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestReconciliationReport(gomock.Any()).Times(0)
+			},
+			buildContext: withUser(user.Username, util.RoleCustomer),
+			checkResponse: func(t *testing.T, res *pb.GetReconciliationReportResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "NoReport",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestReconciliationReport(gomock.Any()).Times(1).Return(db.ReconciliationReport{}, sql.ErrNoRows)
+			},
+			buildContext: withUser(admin.Username, util.RoleAdmin),
+			checkResponse: func(t *testing.T, res *pb.GetReconciliationReportResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_GetReconciliationReport_FullMethodName)
+			res, err := server.GetReconciliationReport(ctx, &pb.GetReconciliationReportRequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recentExecutionsLimit is how many executions GetStandingOrder returns.
+const recentExecutionsLimit = 10
+
+func (server *Server) GetStandingOrder(ctx context.Context, req *pb.GetStandingOrderRequest) (*pb.GetStandingOrderResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetStandingOrderRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	order, err := server.ownedStandingOrder(ctx, payload.Username, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	executions, err := server.store.ListStandingOrderExecutions(ctx, db.ListStandingOrderExecutionsParams{
+		StandingOrderID: order.ID,
+		Limit:           recentExecutionsLimit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to list executions:%s", err)
+	}
+
+	resp := &pb.GetStandingOrderResponse{
+		StandingOrder: convertStandingOrder(order),
+	}
+	for _, execution := range executions {
+		resp.Executions = append(resp.Executions, convertStandingOrderExecution(execution))
+	}
+	return resp, nil
+}
+
+func validateGetStandingOrderRequest(req *pb.GetStandingOrderRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
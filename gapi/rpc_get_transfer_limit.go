@@ -0,0 +1,106 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetTransferLimit(ctx context.Context, req *pb.GetTransferLimitRequest) (*pb.GetTransferLimitResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateTransferLimitScope(req.GetCurrency(), req.GetAccountId())
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	currency, err := server.transferLimitCurrency(ctx, payload, req.GetCurrency(), req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := server.store.ListTransferLimits(ctx, db.ListTransferLimitsParams{
+		Currency:  currency,
+		Username:  payload.Username,
+		AccountID: req.GetAccountId(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to list transfer limits:%s", err)
+	}
+	limit, _ := db.EffectiveTransferLimit(rules)
+	limit.Currency = currency
+
+	resp, err := server.convertTransferLimit(ctx, payload.Username, limit, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetTransferLimitResponse{Limit: resp}, nil
+}
+
+// transferLimitCurrency returns the currency of the limits on accountID,
+// which must belong to the user, or currency if accountID is not set.
+func (server *Server) transferLimitCurrency(ctx context.Context, payload *token.Payload, currency string, accountID int64) (string, error) {
+	if accountID == 0 {
+		return currency, nil
+	}
+	acc, err := server.getOwnedAccount(ctx, payload, accountID)
+	if err != nil {
+		return "", err
+	}
+	return acc.Currency, nil
+}
+
+// convertTransferLimit adds to limit what the user sent today in its scope.
+func (server *Server) convertTransferLimit(ctx context.Context, username string, limit db.TransferLimit, accountID int64) (*pb.TransferLimit, error) {
+	usage, err := server.store.GetDailyTransferUsage(ctx, db.GetDailyTransferUsageParams{
+		AccountID: accountID,
+		Owner:     username,
+		Currency:  limit.Currency,
+		Since:     db.StartOfDay(time.Now()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to get transfer usage:%s", err)
+	}
+
+	sum, count := usage.UserAmount, usage.UserCount
+	if accountID != 0 {
+		sum, count = usage.AccountAmount, usage.AccountCount
+	}
+	return &pb.TransferLimit{
+		Currency:        limit.Currency,
+		AccountId:       accountID,
+		MaxSingleAmount: limit.MaxSingleAmount,
+		MaxDailyAmount:  limit.MaxDailyAmount,
+		MaxDailyCount:   limit.MaxDailyCount,
+		DailyAmountUsed: sum,
+		DailyCountUsed:  int32(count),
+	}, nil
+}
+
+func validateTransferLimitScope(currency string, accountID int64) (violations []*errdetails.BadRequest_FieldViolation) {
+	if accountID != 0 {
+		if err := val.ValidateAccountId(accountID); err != nil {
+			violations = append(violations, fieldViolation("account_id", err))
+		}
+		if currency != "" {
+			violations = append(violations, fieldViolation("currency", errors.New("must not be set together with account_id")))
+		}
+		return violations
+	}
+
+	if err := val.ValidateCurrency(currency); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	return violations
+}
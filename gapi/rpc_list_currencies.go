@@ -0,0 +1,22 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/dibrito/simple-bank/currency"
+	"github.com/dibrito/simple-bank/pb"
+)
+
+func (server *Server) ListCurrencies(ctx context.Context, req *pb.ListCurrenciesRequest) (*pb.ListCurrenciesResponse, error) {
+	enabled := currency.List()
+	currencies := make([]*pb.Currency, 0, len(enabled))
+	for _, c := range enabled {
+		currencies = append(currencies, &pb.Currency{
+			Code:        c.Code,
+			NumericCode: c.NumericCode,
+			MinorUnit:   c.MinorUnit,
+		})
+	}
+
+	return &pb.ListCurrenciesResponse{Currencies: currencies}, nil
+}
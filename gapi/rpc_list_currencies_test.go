@@ -0,0 +1,25 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCurrenciesApi(t *testing.T) {
+	server := newTestServer(t, nil, nil)
+
+	res, err := server.ListCurrencies(context.Background(), &pb.ListCurrenciesRequest{})
+	require.NoError(t, err)
+
+	codes := make([]string, 0, len(res.GetCurrencies()))
+	for _, c := range res.GetCurrencies() {
+		require.NotZero(t, c.GetNumericCode())
+		require.Equal(t, int32(2), c.GetMinorUnit())
+		codes = append(codes, c.GetCode())
+	}
+	require.Equal(t, []string{util.BRL, util.EUR, util.USD}, codes)
+}
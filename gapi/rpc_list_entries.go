@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListEntries(ctx context.Context, req *pb.ListEntriesRequest) (*pb.ListEntriesResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	filter := statementFilter{
+		accountID:      req.GetAccountId(),
+		pageSize:       req.GetPageSize(),
+		pageToken:      req.GetPageToken(),
+		startTime:      req.GetStartTime(),
+		endTime:        req.GetEndTime(),
+		direction:      req.GetDirection(),
+		minAmount:      req.MinAmount,
+		maxAmount:      req.MaxAmount,
+		counterpartyID: req.CounterpartyAccountId,
+	}
+	violations := validateStatementFilter(filter)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	if _, err := server.getOwnedAccount(ctx, payload, req.GetAccountId()); err != nil {
+		return nil, err
+	}
+
+	arg := db.ListEntriesParams{
+		AccountID:      req.GetAccountId(),
+		StartTime:      nullTime(req.GetStartTime()),
+		EndTime:        nullTime(req.GetEndTime()),
+		Direction:      directionName(req.GetDirection()),
+		MinAmount:      nullInt64(req.MinAmount),
+		MaxAmount:      nullInt64(req.MaxAmount),
+		CounterpartyID: nullInt64(req.CounterpartyAccountId),
+		// one extra row tells whether there is a next page
+		PageSize: req.GetPageSize() + 1,
+	}
+	if req.GetPageToken() != "" {
+		cursor, _ := decodePageToken(req.GetPageToken())
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = sql.NullInt64{Int64: cursor.ID, Valid: true}
+	}
+
+	entries, err := server.store.ListEntries(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to list entries:%s", err)
+	}
+
+	resp := &pb.ListEntriesResponse{}
+	if len(entries) > int(req.GetPageSize()) {
+		entries = entries[:req.GetPageSize()]
+		last := entries[len(entries)-1]
+		resp.NextPageToken = encodePageToken(last.CreatedAt, last.ID)
+	}
+	for _, v := range entries {
+		resp.Entries = append(resp.Entries, convertEntry(v))
+	}
+	return resp, nil
+}
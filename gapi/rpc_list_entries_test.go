@@ -0,0 +1,209 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func randomEntries(accountID int64, n int) []db.Entry {
+	entries := make([]db.Entry, n)
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	for i := range entries {
+		entries[i] = db.Entry{
+			ID:        int64(n - i),
+			AccountID: accountID,
+			Amount:    util.RandonMoney(),
+			CreatedAt: createdAt.Add(-time.Duration(i) * time.Second),
+		}
+	}
+	return entries
+}
+
+func TestListEntriesApi(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	pageSize := int32(5)
+	entries := randomEntries(account.ID, int(pageSize)+1)
+	cursor := entries[pageSize-1]
+	startTime := time.Now().Add(-time.Hour)
+
+	tcs := []struct {
+		name          string
+		req           *pb.ListEntriesRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ListEntriesResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ListEntriesRequest{
+				AccountId: account.ID,
+				PageSize:  pageSize,
+				StartTime: timestamppb.New(startTime),
+				Direction: pb.Direction_DIRECTION_CREDIT,
+				MinAmount: proto.Int64(1),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.ListEntriesParams{
+					AccountID: account.ID,
+					StartTime: sql.NullTime{Time: timestamppb.New(startTime).AsTime(), Valid: true},
+					Direction: sql.NullString{String: "credit", Valid: true},
+					MinAmount: sql.NullInt64{Int64: 1, Valid: true},
+					PageSize:  pageSize + 1,
+				}
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entries, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListEntriesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetEntries(), int(pageSize))
+				require.NotEmpty(t, res.GetNextPageToken())
+
+				next, err := decodePageToken(res.GetNextPageToken())
+				require.NoError(t, err)
+				require.Equal(t, cursor.ID, next.ID)
+				require.True(t, cursor.CreatedAt.Equal(next.CreatedAt))
+			},
+		},
+		{
+			name: "LastPage",
+			req: &pb.ListEntriesRequest{
+				AccountId: account.ID,
+				PageSize:  pageSize,
+				PageToken: encodePageToken(cursor.CreatedAt, cursor.ID),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.ListEntriesParams{
+					AccountID:       account.ID,
+					CursorCreatedAt: sql.NullTime{Time: cursor.CreatedAt, Valid: true},
+					CursorID:        sql.NullInt64{Int64: cursor.ID, Valid: true},
+					PageSize:        pageSize + 1,
+				}
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entries[pageSize:], nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListEntriesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetEntries(), 1)
+				require.Empty(t, res.GetNextPageToken())
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req:  &pb.ListEntriesRequest{AccountId: account.ID, PageSize: pageSize},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "unauthorized_user", util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListEntriesResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "InvalidPageToken",
+			req:  &pb.ListEntriesRequest{AccountId: account.ID, PageSize: pageSize, PageToken: "not-a-token"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListEntriesResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "InvalidAmountRange",
+			req: &pb.ListEntriesRequest{
+				AccountId: account.ID,
+				PageSize:  pageSize,
+				MinAmount: proto.Int64(100),
+				MaxAmount: proto.Int64(10),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListEntriesResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "AccountNotFound",
+			req:  &pb.ListEntriesRequest{AccountId: account.ID, PageSize: pageSize},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().ListEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListEntriesResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_ListEntries_FullMethodName)
+			res, err := server.ListEntries(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListSessionsApi(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+
+	sessions := make([]db.Session, 2)
+	for i := range sessions {
+		id := uuid.New()
+		sessions[i] = db.Session{
+			ID:           id,
+			FamilyID:     id,
+			Username:     user.Username,
+			RefreshToken: util.RandomString(32),
+			UserAgent:    "test",
+			ClientIp:     "127.0.0.1",
+			ExpiresAt:    time.Now().Add(time.Hour),
+			CreatedAt:    time.Now(),
+		}
+	}
+
+	withUser := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+	}
+
+	tcs := []struct {
+		name          string
+		req           *pb.ListSessionsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ListSessionsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.ListSessionsRequest{PageId: 2, PageSize: 5},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListSessionsByUser(gomock.Any(), gomock.Eq(db.ListSessionsByUserParams{
+						Username: user.Username,
+						Limit:    5,
+						Offset:   5,
+					})).
+					Times(1).
+					Return(sessions, nil)
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.ListSessionsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetSessions(), len(sessions))
+				for i, session := range res.GetSessions() {
+					require.Equal(t, sessions[i].ID.String(), session.GetId())
+					require.Equal(t, sessions[i].UserAgent, session.GetUserAgent())
+					require.Equal(t, sessions[i].ClientIp, session.GetClientIp())
+				}
+			},
+		},
+		{
+			// the sessions listed are always the caller's own
+			name: "OtherUser",
+			req:  &pb.ListSessionsRequest{PageId: 1, PageSize: 5},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListSessionsByUser(gomock.Any(), gomock.Eq(db.ListSessionsByUserParams{
+						Username: other.Username,
+						Limit:    5,
+					})).
+					Times(1).
+					Return([]db.Session{}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, other.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListSessionsResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetSessions())
+			},
+		},
+		{
+			name: "InvalidPage",
+			req:  &pb.ListSessionsRequest{PageId: 0, PageSize: 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSessionsByUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.ListSessionsResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, []string{"page_id", "page_size"}, violationFields(t, err))
+			},
+		},
+		{
+			name: "InternalError",
+			req:  &pb.ListSessionsRequest{PageId: 1, PageSize: 5},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSessionsByUser(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.ListSessionsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req:  &pb.ListSessionsRequest{PageId: 1, PageSize: 5},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSessionsByUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.ListSessionsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_ListSessions_FullMethodName)
+			res, err := server.ListSessions(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
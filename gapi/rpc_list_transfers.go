@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	filter := statementFilter{
+		accountID:      req.GetAccountId(),
+		pageSize:       req.GetPageSize(),
+		pageToken:      req.GetPageToken(),
+		startTime:      req.GetStartTime(),
+		endTime:        req.GetEndTime(),
+		direction:      req.GetDirection(),
+		minAmount:      req.MinAmount,
+		maxAmount:      req.MaxAmount,
+		counterpartyID: req.CounterpartyAccountId,
+	}
+	violations := validateStatementFilter(filter)
+	violations = append(violations, validateTransferFilter(req)...)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	if _, err := server.getOwnedAccount(ctx, payload, req.GetAccountId()); err != nil {
+		return nil, err
+	}
+
+	arg := db.ListTransfersParams{
+		AccountID:      req.GetAccountId(),
+		StartTime:      nullTime(req.GetStartTime()),
+		EndTime:        nullTime(req.GetEndTime()),
+		Direction:      directionName(req.GetDirection()),
+		MinAmount:      nullInt64(req.MinAmount),
+		MaxAmount:      nullInt64(req.MaxAmount),
+		CounterpartyID: nullInt64(req.CounterpartyAccountId),
+		Tag:            nullString(req.GetTag()),
+		Search:         nullString(req.GetSearch()),
+		// one extra row tells whether there is a next page
+		PageSize: req.GetPageSize() + 1,
+	}
+	if req.GetPageToken() != "" {
+		cursor, _ := decodePageToken(req.GetPageToken())
+		arg.CursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = sql.NullInt64{Int64: cursor.ID, Valid: true}
+	}
+
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to list transfers:%s", err)
+	}
+
+	resp := &pb.ListTransfersResponse{}
+	if len(transfers) > int(req.GetPageSize()) {
+		transfers = transfers[:req.GetPageSize()]
+		last := transfers[len(transfers)-1]
+		resp.NextPageToken = encodePageToken(last.CreatedAt, last.ID)
+	}
+	for _, v := range transfers {
+		resp.Transfers = append(resp.Transfers, convertTransfer(v))
+	}
+	return resp, nil
+}
+
+// validateTransferFilter checks the filters only transfers have.
+func validateTransferFilter(req *pb.ListTransfersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetTag() != "" {
+		if err := val.ValidateTag(req.GetTag()); err != nil {
+			violations = append(violations, fieldViolation("tag", err))
+		}
+	}
+
+	if err := val.ValidateString(req.GetSearch(), 0, 100); err != nil {
+		violations = append(violations, fieldViolation("search", err))
+	}
+
+	return violations
+}
@@ -32,13 +32,13 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, status.Errorf(codes.NotFound, "incorrect password")
 	}
 
-	accessToken, accessTokenPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.AccessDuration)
+	accessToken, accessTokenPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.AccessDuration)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "create token")
 	}
 
 	// create refresh token
-	refreshToken, refreshTokenPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.RefreshDuration)
+	refreshToken, refreshTokenPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.RefreshDuration)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "create refresh token")
 	}
@@ -52,6 +52,7 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		ClientIp:     md.ClientIP,
 		IsBlocked:    false,
 		ExpiresAt:    refreshTokenPayload.ExpireAt,
+		FamilyID:     refreshTokenPayload.ID,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "create session")
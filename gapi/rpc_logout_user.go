@@ -0,0 +1,39 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/dibrito/simple-bank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LogoutUser blocks the session of the refresh token, with the sessions it
+// was rotated from. The access token the call is authorized with, if any,
+// is revoked too; other access tokens stay valid until they expire.
+func (server *Server) LogoutUser(ctx context.Context, req *pb.LogoutUserRequest) (*pb.LogoutUserResponse, error) {
+	violations := validateRefreshTokenRequest(req.GetRefreshToken())
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	session, err := server.activeSession(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.store.BlockSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to block session:%s", err)
+	}
+
+	payload, err := server.authorizeUser(ctx)
+	if err == nil && payload.Username == session.Username {
+		err = server.revoker.RevokeToken(ctx, payload)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "fail to revoke access token:%s", err)
+		}
+	}
+
+	return &pb.LogoutUserResponse{}, nil
+}
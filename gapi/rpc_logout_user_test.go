@@ -0,0 +1,193 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogoutUserApi(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+
+	tcs := []struct {
+		name         string
+		buildSession func(session *db.Session)
+		// accessUser is who the access token of the call belongs to, none when empty
+		accessUser    string
+		buildStubs    func(store *mockdb.MockStore, session db.Session)
+		checkResponse func(t *testing.T, res *pb.LogoutUserResponse, err error, accessRevoked bool)
+	}{
+		{
+			name:         "OK",
+			buildSession: func(session *db.Session) {},
+			accessUser:   user.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).Times(1)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error, accessRevoked bool) {
+				require.NoError(t, err)
+				require.True(t, accessRevoked)
+			},
+		},
+		{
+			name:         "NoAccessToken",
+			buildSession: func(session *db.Session) {},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).Times(1)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error, accessRevoked bool) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:         "AccessTokenOfAnotherUser",
+			buildSession: func(session *db.Session) {},
+			accessUser:   other.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).Times(1)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error, accessRevoked bool) {
+				require.NoError(t, err)
+				// the session is ended, but nobody else's access token is revoked
+				require.False(t, accessRevoked)
+			},
+		},
+		{
+			name: "AlreadyBlocked",
+			buildSession: func(session *db.Session) {
+				session.IsBlocked = true
+			},
+			accessUser: user.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error, accessRevoked bool) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+				require.False(t, accessRevoked)
+			},
+		},
+		{
+			name: "SessionOfAnotherUser",
+			buildSession: func(session *db.Session) {
+				session.Username = other.Username
+			},
+			accessUser: other.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error, accessRevoked bool) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+				require.False(t, accessRevoked)
+			},
+		},
+		{
+			name:         "SessionNotFound",
+			buildSession: func(session *db.Session) {},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{}, sql.ErrNoRows)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error, accessRevoked bool) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name:         "InternalError",
+			buildSession: func(session *db.Session) {},
+			accessUser:   user.Username,
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error, accessRevoked bool) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+				require.False(t, accessRevoked)
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store, nil)
+
+			refreshToken, payload, err := server.tokenMaker.CreateToken(user.Username, util.RoleCustomer, time.Hour)
+			require.NoError(t, err)
+			session := db.Session{
+				ID:           payload.ID,
+				Username:     user.Username,
+				RefreshToken: refreshToken,
+				ExpiresAt:    payload.ExpireAt,
+				FamilyID:     payload.ID,
+			}
+			tc.buildSession(&session)
+			tc.buildStubs(store, session)
+
+			ctx := context.Background()
+			var accessPayload *token.Payload
+			if tc.accessUser != "" {
+				ctx = newContextWithBearerToken(t, server.tokenMaker, tc.accessUser, util.RoleCustomer, time.Minute)
+				accessPayload, err = server.authorizeUser(withRPCMethod(ctx, pb.SimpleBank_LogoutUser_FullMethodName))
+				require.NoError(t, err)
+			}
+
+			ctx = withRPCMethod(ctx, pb.SimpleBank_LogoutUser_FullMethodName)
+			res, err := server.LogoutUser(ctx, &pb.LogoutUserRequest{RefreshToken: refreshToken})
+
+			accessRevoked := accessPayload != nil && server.revoker.CheckToken(context.Background(), accessPayload) != nil
+			tc.checkResponse(t, res, err, accessRevoked)
+		})
+	}
+}
+
+func TestLogoutUserInvalidRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+	server := newTestServer(t, store, nil)
+
+	ctx := withRPCMethod(context.Background(), pb.SimpleBank_LogoutUser_FullMethodName)
+	_, err := server.LogoutUser(ctx, &pb.LogoutUserRequest{})
+	require.Error(t, err)
+	require.Equal(t, []string{"refresh_token"}, violationFields(t, err))
+
+	_, err = server.LogoutUser(ctx, &pb.LogoutUserRequest{RefreshToken: "invalid"})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+}
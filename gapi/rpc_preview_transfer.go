@@ -0,0 +1,42 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/dibrito/simple-bank/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func (server *Server) PreviewTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.PreviewTransferResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateTransferRequest(req, server.extractMetadata(ctx))
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	// a quote is only read when consumed, the preview uses the market rate
+	preview := proto.Clone(req).(*pb.CreateTransferRequest)
+	preview.QuoteId = ""
+
+	arg, toAccount, err := server.prepareTransfer(ctx, payload, preview)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.PreviewTransferResponse{
+		FromAccountId: arg.FromAccountID,
+		ToAccountId:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Fee:           arg.Fee,
+		TotalDebit:    arg.Amount + arg.Fee,
+		Currency:      req.GetCurrency(),
+		ToAmount:      arg.ToAmount,
+		ToCurrency:    toAccount.Currency,
+		ExchangeRate:  arg.ExchangeRate,
+	}
+	return resp, nil
+}
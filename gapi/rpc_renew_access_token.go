@@ -0,0 +1,116 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+	violations := validateRefreshTokenRequest(req.GetRefreshToken())
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	session, err := server.activeSession(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// the role is read again, a change takes effect on the next renewal
+	user, err := server.store.GetUser(ctx, session.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to get user:%s", err)
+	}
+
+	accessToken, accessTokenPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.AccessDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create token")
+	}
+
+	refreshToken, refreshTokenPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.RefreshDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create refresh token")
+	}
+
+	md := server.extractMetadata(ctx)
+	next, err := server.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		Session: session,
+		NewSession: db.CreateSessionParams{
+			ID:           refreshTokenPayload.ID,
+			Username:     session.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    md.UserAgent,
+			ClientIp:     md.ClientIP,
+			ExpiresAt:    refreshTokenPayload.ExpireAt,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			return nil, status.Errorf(codes.Unauthenticated, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "fail to rotate session:%s", err)
+	}
+
+	resp := &pb.RenewAccessTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpireAt:  timestamppb.New(accessTokenPayload.ExpireAt),
+		RefreshToken:         refreshToken,
+		RefreshTokenExpireAt: timestamppb.New(refreshTokenPayload.ExpireAt),
+		SessionId:            next.ID.String(),
+	}
+	return resp, nil
+}
+
+// activeSession returns the session of refreshToken, if it can still
+// renew access tokens. A consumed session is returned too, it is for
+// RotateSessionTx to detect the reuse.
+func (server *Server) activeSession(ctx context.Context, refreshToken string) (db.Session, error) {
+	payload, err := server.tokenMaker.VerifyToken(refreshToken)
+	if err != nil {
+		return db.Session{}, status.Errorf(codes.Unauthenticated, "invalid refresh token:%s", err)
+	}
+	err = server.revoker.CheckToken(ctx, payload)
+	if err != nil {
+		return db.Session{}, status.Errorf(codes.Unauthenticated, "invalid refresh token:%s", err)
+	}
+
+	session, err := server.store.GetSession(ctx, payload.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return session, status.Errorf(codes.NotFound, "session not found")
+		}
+		return session, status.Errorf(codes.Internal, "fail to get session:%s", err)
+	}
+
+	if session.IsBlocked {
+		return session, status.Errorf(codes.Unauthenticated, "blocked session")
+	}
+	if session.Username != payload.Username {
+		return session, status.Errorf(codes.Unauthenticated, "incorrect session user")
+	}
+	if session.RefreshToken != refreshToken {
+		return session, status.Errorf(codes.Unauthenticated, "mismatched session token")
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return session, status.Errorf(codes.Unauthenticated, "expired session")
+	}
+
+	return session, nil
+}
+
+func validateRefreshTokenRequest(refreshToken string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateRefreshToken(refreshToken); err != nil {
+		violations = append(violations, fieldViolation("refresh_token", err))
+	}
+	return violations
+}
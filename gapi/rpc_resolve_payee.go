@@ -0,0 +1,123 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ResolvePayee(ctx context.Context, req *pb.ResolvePayeeRequest) (*pb.ResolvePayeeResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateResolvePayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	user, account, err := server.resolvePayee(ctx, req.GetUsername(), req.GetEmail(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ResolvePayeeResponse{
+		MaskedFullName: util.MaskFullName(user.FullName),
+		Currency:       account.Currency,
+	}
+	return resp, nil
+}
+
+// resolvePayee finds the account in currency of the user with the given
+// username, or else verified email.
+// Every miss is reported the same way, so callers can't probe which users exist.
+func (server *Server) resolvePayee(ctx context.Context, username, email, code string) (db.User, db.Account, error) {
+	var user db.User
+	var err error
+	if username != "" {
+		user, err = server.store.GetUser(ctx, username)
+	} else {
+		user, err = server.store.GetUserByEmail(ctx, email)
+		if err == nil && !user.IsEmailVerified {
+			err = sql.ErrNoRows
+		}
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return user, db.Account{}, status.Errorf(codes.NotFound, "payee not found")
+		}
+		return user, db.Account{}, status.Errorf(codes.Internal, "fail to get user:%s", err)
+	}
+
+	account, err := server.store.GetAccountByOwnerAndCurrency(ctx, db.GetAccountByOwnerAndCurrencyParams{
+		Owner:    user.Username,
+		Currency: code,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return user, account, status.Errorf(codes.NotFound, "payee not found")
+		}
+		return user, account, status.Errorf(codes.Internal, "fail to get account:%s", err)
+	}
+	return user, account, nil
+}
+
+// validatePayee checks that exactly one way of naming the recipient is set.
+func validatePayee(accountID int64, username, email string, payeeID int64) (violations []*errdetails.BadRequest_FieldViolation) {
+	set := 0
+	for _, isSet := range []bool{accountID != 0, username != "", email != "", payeeID != 0} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return append(violations, fieldViolation("to_account_id", errors.New("exactly one of to_account_id, to_username, to_email or payee_id must be set")))
+	}
+
+	switch {
+	case accountID != 0:
+		if err := val.ValidateAccountId(accountID); err != nil {
+			violations = append(violations, fieldViolation("to_account_id", err))
+		}
+	case payeeID != 0:
+		if err := val.ValidateAccountId(payeeID); err != nil {
+			violations = append(violations, fieldViolation("payee_id", err))
+		}
+	case username != "":
+		if err := val.ValidateUsername(username); err != nil {
+			violations = append(violations, fieldViolation("to_username", err))
+		}
+	default:
+		if err := val.ValidateEmail(email); err != nil {
+			violations = append(violations, fieldViolation("to_email", err))
+		}
+	}
+	return violations
+}
+
+func validateResolvePayeeRequest(req *pb.ResolvePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if (req.GetUsername() == "") == (req.GetEmail() == "") {
+		violations = append(violations, fieldViolation("username", errors.New("exactly one of username or email must be set")))
+	} else if req.GetUsername() != "" {
+		if err := val.ValidateUsername(req.GetUsername()); err != nil {
+			violations = append(violations, fieldViolation("username", err))
+		}
+	} else if err := val.ValidateEmail(req.GetEmail()); err != nil {
+		violations = append(violations, fieldViolation("email", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,170 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolvePayeeApi(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	user2.FullName = "Jane Doe"
+	user2.IsEmailVerified = true
+	account2 := randomAccount(user2.Username)
+	account2.Currency = util.USD
+
+	unverified := user2
+	unverified.IsEmailVerified = false
+
+	withUser1 := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, util.RoleCustomer, time.Minute)
+	}
+	accountArg := db.GetAccountByOwnerAndCurrencyParams{
+		Owner:    user2.Username,
+		Currency: util.USD,
+	}
+
+	tcs := []struct {
+		name          string
+		req           *pb.ResolvePayeeRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ResolvePayeeResponse, err error)
+	}{
+		{
+			name: "ByUsername",
+			req: &pb.ResolvePayeeRequest{
+				Username: user2.Username,
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user2.Username)).Times(1).Return(user2, nil)
+				store.EXPECT().GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Eq(accountArg)).Times(1).Return(account2, nil)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.ResolvePayeeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "J*** D**", res.GetMaskedFullName())
+				require.Equal(t, util.USD, res.GetCurrency())
+			},
+		},
+		{
+			name: "ByEmail",
+			req: &pb.ResolvePayeeRequest{
+				Email:    user2.Email,
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user2.Email)).Times(1).Return(user2, nil)
+				store.EXPECT().GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Eq(accountArg)).Times(1).Return(account2, nil)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.ResolvePayeeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "J*** D**", res.GetMaskedFullName())
+			},
+		},
+		{
+			name: "EmailNotVerified",
+			req: &pb.ResolvePayeeRequest{
+				Email:    user2.Email,
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user2.Email)).Times(1).Return(unverified, nil)
+				store.EXPECT().GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.ResolvePayeeResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "NoAccountInCurrency",
+			req: &pb.ResolvePayeeRequest{
+				Username: user2.Username,
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user2.Username)).Times(1).Return(user2, nil)
+				store.EXPECT().GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Eq(accountArg)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.ResolvePayeeResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "UsernameAndEmail",
+			req: &pb.ResolvePayeeRequest{
+				Username: user2.Username,
+				Email:    user2.Email,
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.ResolvePayeeResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "Unauthenticated",
+			req: &pb.ResolvePayeeRequest{
+				Username: user2.Username,
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.ResolvePayeeResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_ResolvePayee_FullMethodName)
+			res, err := server.ResolvePayee(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRevokeSessionRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	session, err := server.store.GetSession(ctx, uuid.MustParse(req.GetId()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+		return nil, status.Errorf(codes.Internal, "fail to get session:%s", err)
+	}
+
+	if session.Username != payload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "session does not belong to authenticated user")
+	}
+
+	if !session.IsBlocked {
+		err = server.store.BlockSessionFamily(ctx, session.FamilyID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "fail to block session:%s", err)
+		}
+	}
+
+	return &pb.RevokeSessionResponse{}, nil
+}
+
+func validateRevokeSessionRequest(req *pb.RevokeSessionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateSessionID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,96 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateAccount(ctx context.Context, req *pb.UpdateAccountRequest) (*pb.UpdateAccountResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	acc, err := server.getOwnedAccount(ctx, payload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if acc.Status == db.AccountStatusClosed {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", db.ErrAccountClosed)
+	}
+
+	// the status goes first, it is the change that can be refused
+	if req.Status != nil {
+		acc, err = server.store.UpdateAccountStatus(ctx, db.UpdateAccountStatusParams{
+			Status:   req.GetStatus(),
+			Username: payload.Username,
+			ID:       acc.ID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, server.accountStatusLocked(ctx, req.GetId())
+			}
+			return nil, status.Errorf(codes.Internal, "fail to update account:%s", err)
+		}
+	}
+
+	if req.Nickname != nil {
+		acc, err = server.store.UpdateAccountNickname(ctx, db.UpdateAccountNicknameParams{
+			Nickname: req.GetNickname(),
+			ID:       acc.ID,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "fail to update account:%s", err)
+		}
+	}
+
+	resp := &pb.UpdateAccountResponse{
+		Account: convertAccount(acc),
+	}
+	return resp, nil
+}
+
+// accountStatusLocked explains why the status of account id could not be
+// changed: it was closed, or frozen by the bank, since it was read.
+func (server *Server) accountStatusLocked(ctx context.Context, id int64) error {
+	acc, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "fail to get account:%s", err)
+	}
+	if acc.Status == db.AccountStatusClosed {
+		return status.Errorf(codes.FailedPrecondition, "%s", db.ErrAccountClosed)
+	}
+	return status.Errorf(codes.FailedPrecondition, "account is frozen by the bank")
+}
+
+func validateUpdateAccountRequest(req *pb.UpdateAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if req.Nickname != nil {
+		if err := val.ValidateAccountNickname(req.GetNickname()); err != nil {
+			violations = append(violations, fieldViolation("nickname", err))
+		}
+	}
+
+	if req.Status != nil {
+		if err := val.ValidateAccountStatus(req.GetStatus()); err != nil {
+			violations = append(violations, fieldViolation("status", err))
+		}
+	}
+
+	return violations
+}
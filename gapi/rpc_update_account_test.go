@@ -0,0 +1,228 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateAccountApi(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	nickname := "groceries"
+	frozen := db.AccountStatusFrozen
+	active := db.AccountStatusActive
+	closed := db.AccountStatusClosed
+
+	tcs := []struct {
+		name          string
+		req           *pb.UpdateAccountRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.UpdateAccountResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.UpdateAccountRequest{
+				Id:       account.ID,
+				Nickname: &nickname,
+				Status:   &frozen,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				frozenAccount := account
+				frozenAccount.Status = frozen
+				updated := frozenAccount
+				updated.Nickname = nickname
+
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					UpdateAccountNickname(gomock.Any(), gomock.Eq(db.UpdateAccountNicknameParams{
+						Nickname: nickname,
+						ID:       account.ID,
+					})).
+					Times(1).
+					Return(updated, nil)
+				store.EXPECT().
+					UpdateAccountStatus(gomock.Any(), gomock.Eq(db.UpdateAccountStatusParams{
+						Status:   frozen,
+						Username: user.Username,
+						ID:       account.ID,
+					})).
+					Times(1).
+					Return(frozenAccount, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, nickname, res.GetAccount().Nickname)
+				require.Equal(t, frozen, res.GetAccount().Status)
+			},
+		},
+		{
+			name: "CannotClose",
+			req: &pb.UpdateAccountRequest{
+				Id:     account.ID,
+				Status: &closed,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, []string{"status"}, violationFields(t, err))
+			},
+		},
+		{
+			name: "AccountClosed",
+			req: &pb.UpdateAccountRequest{
+				Id:       account.ID,
+				Nickname: &nickname,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				closedAccount := account
+				closedAccount.Status = closed
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(closedAccount, nil)
+				store.EXPECT().
+					UpdateAccountNickname(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "ClosedMeanwhile",
+			req: &pb.UpdateAccountRequest{
+				Id:       account.ID,
+				Nickname: &nickname,
+				Status:   &frozen,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				closedAccount := account
+				closedAccount.Status = closed
+				gomock.InOrder(
+					store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil),
+					store.EXPECT().UpdateAccountStatus(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows),
+					store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(closedAccount, nil),
+				)
+				store.EXPECT().
+					UpdateAccountNickname(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+				require.Equal(t, db.ErrAccountClosed.Error(), st.Message())
+			},
+		},
+		{
+			name: "FrozenByBank",
+			req: &pb.UpdateAccountRequest{
+				Id:     account.ID,
+				Status: &active,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				frozenAccount := account
+				frozenAccount.Status = frozen
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(2).
+					Return(frozenAccount, nil)
+				store.EXPECT().
+					UpdateAccountStatus(gomock.Any(), gomock.Eq(db.UpdateAccountStatusParams{
+						Status:   active,
+						Username: user.Username,
+						ID:       account.ID,
+					})).
+					Times(1).
+					Return(db.Account{}, sql.ErrNoRows)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req: &pb.UpdateAccountRequest{
+				Id:     account.ID,
+				Status: &frozen,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					UpdateAccountStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, other.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_UpdateAccount_FullMethodName)
+			res, err := server.UpdateAccount(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
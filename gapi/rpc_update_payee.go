@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdatePayee(ctx context.Context, req *pb.UpdatePayeeRequest) (*pb.UpdatePayeeResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdatePayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	payee, err := server.ownedPayee(ctx, payload.Username, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	payee, err = server.store.UpdatePayeeNickname(ctx, db.UpdatePayeeNicknameParams{
+		ID:       payee.ID,
+		Nickname: req.GetNickname(),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "payee nickname already exists:%s", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "fail to update payee:%s", err)
+	}
+
+	resp := &pb.UpdatePayeeResponse{
+		Payee: server.convertPayee(payee),
+	}
+	return resp, nil
+}
+
+func validateUpdatePayeeRequest(req *pb.UpdatePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if err := val.ValidateNickname(req.GetNickname()); err != nil {
+		violations = append(violations, fieldViolation("nickname", err))
+	}
+
+	return violations
+}
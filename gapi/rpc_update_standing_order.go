@@ -0,0 +1,116 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateStandingOrder(ctx context.Context, req *pb.UpdateStandingOrderRequest) (*pb.UpdateStandingOrderResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateStandingOrderRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	order, err := server.ownedStandingOrder(ctx, payload.Username, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == db.StandingOrderStatusCompleted || order.Status == db.StandingOrderStatusCancelled {
+		return nil, status.Errorf(codes.FailedPrecondition, "standing order is %s", order.Status)
+	}
+
+	arg := db.UpdateStandingOrderParams{
+		ID: order.ID,
+		Amount: sql.NullInt64{
+			Int64: req.GetAmount(),
+			Valid: req.Amount != nil,
+		},
+		Schedule: sql.NullString{
+			String: req.GetSchedule(),
+			Valid:  req.Schedule != nil,
+		},
+		Status: sql.NullString{
+			String: req.GetStatus(),
+			Valid:  req.Status != nil,
+		},
+	}
+	if req.EndAt != nil {
+		arg.EndAt = sql.NullTime{Time: req.GetEndAt().AsTime(), Valid: true}
+	}
+
+	// a new schedule or a resumed order starts from now, runs missed while
+	// paused are skipped
+	nextRunAt := order.NextRunAt
+	resumed := order.Status == db.StandingOrderStatusPaused && req.GetStatus() == db.StandingOrderStatusActive
+	if arg.Schedule.Valid || resumed {
+		schedule := order.Schedule
+		if arg.Schedule.Valid {
+			schedule = arg.Schedule.String
+		}
+		nextRunAt, err = util.NextRun(schedule, time.Now())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+		arg.NextRunAt = sql.NullTime{Time: nextRunAt, Valid: true}
+	}
+
+	endAt := order.EndAt
+	if arg.EndAt.Valid {
+		endAt = arg.EndAt
+	}
+	if endAt.Valid && nextRunAt.After(endAt.Time) {
+		return nil, status.Errorf(codes.InvalidArgument, "end_at is before the next run at %s", nextRunAt.Format(time.RFC3339))
+	}
+
+	order, err = server.store.UpdateStandingOrder(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to update standing order:%s", err)
+	}
+
+	resp := &pb.UpdateStandingOrderResponse{
+		StandingOrder: convertStandingOrder(order),
+	}
+	return resp, nil
+}
+
+func validateUpdateStandingOrderRequest(req *pb.UpdateStandingOrderRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	if req.Schedule != nil {
+		if err := val.ValidateSchedule(req.GetSchedule()); err != nil {
+			violations = append(violations, fieldViolation("schedule", err))
+		}
+	}
+
+	if req.Status != nil {
+		if s := req.GetStatus(); s != db.StandingOrderStatusActive && s != db.StandingOrderStatusPaused {
+			err := fmt.Errorf("must be %s or %s", db.StandingOrderStatusActive, db.StandingOrderStatusPaused)
+			violations = append(violations, fieldViolation("status", err))
+		}
+	}
+
+	return violations
+}
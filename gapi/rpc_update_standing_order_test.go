@@ -0,0 +1,204 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomStandingOrder(owner string) db.StandingOrder {
+	return db.StandingOrder{
+		ID:            util.RandomInt(1, 1000),
+		Owner:         owner,
+		FromAccountID: util.RandomInt(1, 1000),
+		ToAccountID:   util.RandomInt(1, 1000),
+		Amount:        util.RandomInt(1, 1000),
+		Currency:      util.USD,
+		Schedule:      "@monthly",
+		NextRunAt:     time.Now().Add(24 * time.Hour),
+		Status:        db.StandingOrderStatusActive,
+	}
+}
+
+func TestUpdateStandingOrderApi(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	order := randomStandingOrder(user1.Username)
+
+	paused := order
+	paused.Status = db.StandingOrderStatusPaused
+	paused.NextRunAt = time.Now().Add(-24 * time.Hour)
+
+	cancelled := order
+	cancelled.Status = db.StandingOrderStatusCancelled
+
+	withUser1 := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, util.RoleCustomer, time.Minute)
+	}
+	newAmount := int64(42)
+	active := db.StandingOrderStatusActive
+
+	tcs := []struct {
+		name          string
+		req           *pb.UpdateStandingOrderRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.UpdateStandingOrderResponse, err error)
+	}{
+		{
+			name: "UpdateAmount",
+			req: &pb.UpdateStandingOrderRequest{
+				Id:     order.ID,
+				Amount: &newAmount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStandingOrder(gomock.Any(), gomock.Eq(order.ID)).Times(1).Return(order, nil)
+
+				updated := order
+				updated.Amount = newAmount
+				arg := db.UpdateStandingOrderParams{
+					ID:     order.ID,
+					Amount: sql.NullInt64{Int64: newAmount, Valid: true},
+				}
+				store.EXPECT().UpdateStandingOrder(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.UpdateStandingOrderResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, newAmount, res.GetStandingOrder().GetAmount())
+			},
+		},
+		{
+			name: "ResumeSkipsMissedRuns",
+			req: &pb.UpdateStandingOrderRequest{
+				Id:     paused.ID,
+				Status: &active,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStandingOrder(gomock.Any(), gomock.Eq(paused.ID)).Times(1).Return(paused, nil)
+				store.EXPECT().
+					UpdateStandingOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateStandingOrderParams) (db.StandingOrder, error) {
+						require.Equal(t, active, arg.Status.String)
+						require.True(t, arg.NextRunAt.Valid)
+						require.True(t, arg.NextRunAt.Time.After(time.Now()))
+
+						resumed := paused
+						resumed.Status = arg.Status.String
+						resumed.NextRunAt = arg.NextRunAt.Time
+						return resumed, nil
+					})
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.UpdateStandingOrderResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, active, res.GetStandingOrder().GetStatus())
+			},
+		},
+		{
+			name: "Cancelled",
+			req: &pb.UpdateStandingOrderRequest{
+				Id:     cancelled.ID,
+				Amount: &newAmount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStandingOrder(gomock.Any(), gomock.Eq(cancelled.ID)).Times(1).Return(cancelled, nil)
+				store.EXPECT().UpdateStandingOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.UpdateStandingOrderResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req: &pb.UpdateStandingOrderRequest{
+				Id:     order.ID,
+				Amount: &newAmount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStandingOrder(gomock.Any(), gomock.Eq(order.ID)).Times(1).Return(order, nil)
+				store.EXPECT().UpdateStandingOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateStandingOrderResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.UpdateStandingOrderRequest{
+				Id:     order.ID,
+				Amount: &newAmount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStandingOrder(gomock.Any(), gomock.Eq(order.ID)).Times(1).Return(db.StandingOrder{}, sql.ErrNoRows)
+				store.EXPECT().UpdateStandingOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.UpdateStandingOrderResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InvalidStatus",
+			req: func() *pb.UpdateStandingOrderRequest {
+				completed := db.StandingOrderStatusCompleted
+				return &pb.UpdateStandingOrderRequest{
+					Id:     order.ID,
+					Status: &completed,
+				}
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStandingOrder(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateStandingOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser1,
+			checkResponse: func(t *testing.T, res *pb.UpdateStandingOrderResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_UpdateStandingOrder_FullMethodName)
+			res, err := server.UpdateStandingOrder(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateTransferLimit(ctx context.Context, req *pb.UpdateTransferLimitRequest) (*pb.UpdateTransferLimitResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateTransferLimitRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentsError(violations)
+	}
+
+	currency, err := server.transferLimitCurrency(ctx, payload, req.GetCurrency(), req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := server.store.ListTransferLimits(ctx, db.ListTransferLimitsParams{
+		Currency:  currency,
+		Username:  payload.Username,
+		AccountID: req.GetAccountId(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to list transfer limits:%s", err)
+	}
+
+	// a value left out keeps the current limit, a new one must not exceed it
+	current, limited := db.EffectiveTransferLimit(rules)
+	next := current
+	if req.MaxSingleAmount != nil {
+		if limited && req.GetMaxSingleAmount() > current.MaxSingleAmount {
+			return nil, status.Errorf(codes.PermissionDenied, "limits can only be lowered, max_single_amount is %d", current.MaxSingleAmount)
+		}
+		next.MaxSingleAmount = req.GetMaxSingleAmount()
+	}
+	if req.MaxDailyAmount != nil {
+		if limited && req.GetMaxDailyAmount() > current.MaxDailyAmount {
+			return nil, status.Errorf(codes.PermissionDenied, "limits can only be lowered, max_daily_amount is %d", current.MaxDailyAmount)
+		}
+		next.MaxDailyAmount = req.GetMaxDailyAmount()
+	}
+	if req.MaxDailyCount != nil {
+		if limited && req.GetMaxDailyCount() > current.MaxDailyCount {
+			return nil, status.Errorf(codes.PermissionDenied, "limits can only be lowered, max_daily_count is %d", current.MaxDailyCount)
+		}
+		next.MaxDailyCount = req.GetMaxDailyCount()
+	}
+	if next.MaxSingleAmount == 0 || next.MaxDailyAmount == 0 || next.MaxDailyCount == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "there are no limits in %s yet, all of them must be set", currency)
+	}
+
+	limit, err := server.saveTransferLimit(ctx, rules, db.CreateTransferLimitParams{
+		Username:        sql.NullString{String: payload.Username, Valid: true},
+		AccountID:       sql.NullInt64{Int64: req.GetAccountId(), Valid: req.GetAccountId() != 0},
+		Currency:        currency,
+		MaxSingleAmount: next.MaxSingleAmount,
+		MaxDailyAmount:  next.MaxDailyAmount,
+		MaxDailyCount:   next.MaxDailyCount,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fail to update transfer limit:%s", err)
+	}
+
+	resp, err := server.convertTransferLimit(ctx, payload.Username, limit, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateTransferLimitResponse{Limit: resp}, nil
+}
+
+// saveTransferLimit updates the rule among rules with the scope of arg,
+// or creates it.
+func (server *Server) saveTransferLimit(ctx context.Context, rules []db.TransferLimit, arg db.CreateTransferLimitParams) (db.TransferLimit, error) {
+	for _, rule := range rules {
+		if rule.Username.Valid && rule.AccountID == arg.AccountID {
+			return server.store.UpdateTransferLimit(ctx, db.UpdateTransferLimitParams{
+				ID:              rule.ID,
+				MaxSingleAmount: arg.MaxSingleAmount,
+				MaxDailyAmount:  arg.MaxDailyAmount,
+				MaxDailyCount:   arg.MaxDailyCount,
+			})
+		}
+	}
+	return server.store.CreateTransferLimit(ctx, arg)
+}
+
+func validateUpdateTransferLimitRequest(req *pb.UpdateTransferLimitRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = validateTransferLimitScope(req.GetCurrency(), req.GetAccountId())
+
+	if req.MaxSingleAmount != nil {
+		if err := val.ValidateAmount(req.GetMaxSingleAmount()); err != nil {
+			violations = append(violations, fieldViolation("max_single_amount", err))
+		}
+	}
+
+	if req.MaxDailyAmount != nil {
+		if err := val.ValidateAmount(req.GetMaxDailyAmount()); err != nil {
+			violations = append(violations, fieldViolation("max_daily_amount", err))
+		}
+	}
+
+	if req.MaxDailyCount != nil {
+		if err := val.ValidateAmount(int64(req.GetMaxDailyCount())); err != nil {
+			violations = append(violations, fieldViolation("max_daily_count", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,252 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateTransferLimitApi(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = util.USD
+
+	bankDefault := db.TransferLimit{
+		ID:              1,
+		Currency:        util.USD,
+		MaxSingleAmount: 1000,
+		MaxDailyAmount:  5000,
+		MaxDailyCount:   10,
+	}
+	userRule := db.TransferLimit{
+		ID:              2,
+		Username:        sql.NullString{String: user.Username, Valid: true},
+		Currency:        util.USD,
+		MaxSingleAmount: 800,
+		MaxDailyAmount:  5000,
+		MaxDailyCount:   10,
+	}
+	lower := int64(500)
+	higher := int64(2000)
+	count := int32(3)
+
+	withUser := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+	}
+
+	tcs := []struct {
+		name          string
+		req           *pb.UpdateTransferLimitRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.UpdateTransferLimitResponse, err error)
+	}{
+		{
+			name: "CreateUserRule",
+			req: &pb.UpdateTransferLimitRequest{
+				Currency:        util.USD,
+				MaxSingleAmount: &lower,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListTransferLimits(gomock.Any(), gomock.Eq(db.ListTransferLimitsParams{Currency: util.USD, Username: user.Username})).
+					Times(1).
+					Return([]db.TransferLimit{bankDefault}, nil)
+				arg := db.CreateTransferLimitParams{
+					Username:        sql.NullString{String: user.Username, Valid: true},
+					Currency:        util.USD,
+					MaxSingleAmount: lower,
+					MaxDailyAmount:  bankDefault.MaxDailyAmount,
+					MaxDailyCount:   bankDefault.MaxDailyCount,
+				}
+				store.EXPECT().
+					CreateTransferLimit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.TransferLimit{Currency: util.USD, MaxSingleAmount: lower, MaxDailyAmount: 5000, MaxDailyCount: 10}, nil)
+				store.EXPECT().
+					GetDailyTransferUsage(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetDailyTransferUsageRow{UserAmount: 300, UserCount: 2}, nil)
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.UpdateTransferLimitResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, lower, res.GetLimit().GetMaxSingleAmount())
+				require.Equal(t, int64(300), res.GetLimit().GetDailyAmountUsed())
+				require.Equal(t, int32(2), res.GetLimit().GetDailyCountUsed())
+			},
+		},
+		{
+			name: "UpdateUserRule",
+			req: &pb.UpdateTransferLimitRequest{
+				Currency:      util.USD,
+				MaxDailyCount: &count,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListTransferLimits(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.TransferLimit{bankDefault, userRule}, nil)
+				arg := db.UpdateTransferLimitParams{
+					ID:              userRule.ID,
+					MaxSingleAmount: userRule.MaxSingleAmount,
+					MaxDailyAmount:  userRule.MaxDailyAmount,
+					MaxDailyCount:   count,
+				}
+				store.EXPECT().
+					UpdateTransferLimit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.TransferLimit{Currency: util.USD, MaxSingleAmount: 800, MaxDailyAmount: 5000, MaxDailyCount: count}, nil)
+				store.EXPECT().CreateTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetDailyTransferUsage(gomock.Any(), gomock.Any()).Times(1)
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.UpdateTransferLimitResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, count, res.GetLimit().GetMaxDailyCount())
+			},
+		},
+		{
+			name: "CreateAccountRule",
+			req: &pb.UpdateTransferLimitRequest{
+				AccountId:       account.ID,
+				MaxSingleAmount: &lower,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ListTransferLimits(gomock.Any(), gomock.Eq(db.ListTransferLimitsParams{Currency: util.USD, Username: user.Username, AccountID: account.ID})).
+					Times(1).
+					Return([]db.TransferLimit{bankDefault, userRule}, nil)
+				arg := db.CreateTransferLimitParams{
+					Username:        sql.NullString{String: user.Username, Valid: true},
+					AccountID:       sql.NullInt64{Int64: account.ID, Valid: true},
+					Currency:        util.USD,
+					MaxSingleAmount: lower,
+					MaxDailyAmount:  5000,
+					MaxDailyCount:   10,
+				}
+				store.EXPECT().
+					CreateTransferLimit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.TransferLimit{Currency: util.USD, MaxSingleAmount: lower}, nil)
+				store.EXPECT().
+					GetDailyTransferUsage(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetDailyTransferUsageRow{UserAmount: 300, AccountAmount: 100, AccountCount: 1}, nil)
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.UpdateTransferLimitResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account.ID, res.GetLimit().GetAccountId())
+				require.Equal(t, int64(100), res.GetLimit().GetDailyAmountUsed())
+			},
+		},
+		{
+			name: "Raise",
+			req: &pb.UpdateTransferLimitRequest{
+				Currency:        util.USD,
+				MaxSingleAmount: &higher,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListTransferLimits(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.TransferLimit{bankDefault, userRule}, nil)
+				store.EXPECT().CreateTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.UpdateTransferLimitResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "AccountOfAnotherUser",
+			req: &pb.UpdateTransferLimitRequest{
+				AccountId:       account.ID,
+				MaxSingleAmount: &lower,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListTransferLimits(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, other.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateTransferLimitResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "InvalidArguments",
+			req: &pb.UpdateTransferLimitRequest{
+				Currency:        util.USD,
+				AccountId:       account.ID,
+				MaxSingleAmount: new(int64),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListTransferLimits(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.UpdateTransferLimitResponse, err error) {
+				require.Error(t, err)
+				require.ElementsMatch(t, []string{"currency", "max_single_amount"}, violationFields(t, err))
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.UpdateTransferLimitRequest{
+				Currency:        util.USD,
+				MaxSingleAmount: &lower,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransferLimits(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateTransferLimitResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_UpdateTransferLimit_FullMethodName)
+			res, err := server.UpdateTransferLimit(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -65,6 +65,14 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, status.Errorf(codes.Internal, "fail to update user:%s", err)
 	}
 
+	// tokens issued with the old password must not outlive it
+	if arg.PasswordChangedAt.Valid {
+		err = server.revoker.RevokeUserTokens(ctx, u.Username, arg.PasswordChangedAt.Time)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "fail to revoke user tokens:%s", err)
+		}
+	}
+
 	resp := &pb.UpdateUserResponse{
 		User: convertUser(u),
 	}
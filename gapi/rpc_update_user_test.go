@@ -0,0 +1,141 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/dibrito/simple-bank/db/mocks"
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateUserApi(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	newName := util.RandomOwner()
+	newPassword := util.RandomString(6)
+
+	withUser := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user.Username, util.RoleCustomer, time.Minute)
+	}
+
+	tcs := []struct {
+		name         string
+		req          *pb.UpdateUserRequest
+		buildStubs   func(store *mockdb.MockStore)
+		buildContext func(t *testing.T, tokenMaker token.Maker) context.Context
+		// checkResponse gets authErr, the error authorizing the same token
+		// again after the call.
+		checkResponse func(t *testing.T, res *pb.UpdateUserResponse, err, authErr error)
+	}{
+		{
+			name: "FullName",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateUserParams) (db.User, error) {
+						require.False(t, arg.HashedPassword.Valid)
+						updated := user
+						updated.FullName = newName
+						return updated, nil
+					})
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err, authErr error) {
+				require.NoError(t, err)
+				require.Equal(t, newName, res.GetUser().GetFullName())
+				require.NoError(t, authErr)
+			},
+		},
+		{
+			name: "PasswordRevokesTokens",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				Password: &newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateUserParams) (db.User, error) {
+						require.True(t, arg.HashedPassword.Valid)
+						require.NoError(t, util.CheckPassword(newPassword, arg.HashedPassword.String))
+						return user, nil
+					})
+			},
+			buildContext: withUser,
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err, authErr error) {
+				require.NoError(t, err)
+				require.ErrorContains(t, authErr, token.ErrRevokedToken.Error())
+			},
+		},
+		{
+			name: "OtherUser",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				Password: &newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, other.Username, util.RoleCustomer, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err, authErr error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+				require.NoError(t, authErr)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err, authErr error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := withRPCMethod(tc.buildContext(t, server.tokenMaker), pb.SimpleBank_UpdateUser_FullMethodName)
+			res, err := server.UpdateUser(ctx, tc.req)
+			_, authErr := server.authorizeUser(ctx)
+			tc.checkResponse(t, res, err, authErr)
+		})
+	}
+}
@@ -2,8 +2,10 @@ package gapi
 
 import (
 	"fmt"
+	"math/big"
 
 	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fx"
 	"github.com/dibrito/simple-bank/pb"
 	"github.com/dibrito/simple-bank/token"
 	"github.com/dibrito/simple-bank/util"
@@ -22,20 +24,35 @@ type Server struct {
 	config          util.Config
 	store           db.Store
 	tokenMaker      token.Maker
+	revoker         token.Revoker
 	taskDistributer worker.TaskDistributor
+	rates           fx.RateProvider
+	spread          *big.Rat
 }
 
 // NewServer creates a new gRPC server.
-func NewServer(config util.Config, store db.Store, td worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+// Tokens revoked with revoker are rejected.
+func NewServer(config util.Config, store db.Store, td worker.TaskDistributor, revoker token.Revoker) (*Server, error) {
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("can not create token maker: %v", err)
 	}
+	rates, err := fx.NewProvider(config.FXRatesFile)
+	if err != nil {
+		return nil, fmt.Errorf("can not create rate provider: %v", err)
+	}
+	spread, err := fx.ParseSpread(config.FXSpread)
+	if err != nil {
+		return nil, fmt.Errorf("can not parse fx spread: %v", err)
+	}
 	server := &Server{
 		store:           store,
 		config:          config,
 		tokenMaker:      tokenMaker,
+		revoker:         revoker,
 		taskDistributer: td,
+		rates:           rates,
+		spread:          spread,
 	}
 	return server, nil
 }
@@ -0,0 +1,129 @@
+package gapi
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// statementFilter holds the filters shared by ListEntries and ListTransfers.
+type statementFilter struct {
+	accountID      int64
+	pageSize       int32
+	pageToken      string
+	startTime      *timestamppb.Timestamp
+	endTime        *timestamppb.Timestamp
+	direction      pb.Direction
+	minAmount      *int64
+	maxAmount      *int64
+	counterpartyID *int64
+}
+
+// pageCursor is the position of the last row of a page. Rows are ordered by
+// (created_at, id) descending, so the next page starts strictly below it.
+type pageCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int64     `json:"id"`
+}
+
+func encodePageToken(createdAt time.Time, id int64) string {
+	b, _ := json.Marshal(pageCursor{CreatedAt: createdAt, ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(token string) (*pageCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("is not a valid page token")
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(b, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, errors.New("is not a valid page token")
+	}
+	return &cursor, nil
+}
+
+func nullTime(ts *timestamppb.Timestamp) sql.NullTime {
+	if ts == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: ts.AsTime(), Valid: true}
+}
+
+func nullInt64(v *int64) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *v, Valid: true}
+}
+
+func nullString(v string) sql.NullString {
+	if v == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: v, Valid: true}
+}
+
+// directionName maps a Direction to the value the list queries filter on.
+func directionName(d pb.Direction) sql.NullString {
+	switch d {
+	case pb.Direction_DIRECTION_DEBIT:
+		return sql.NullString{String: "debit", Valid: true}
+	case pb.Direction_DIRECTION_CREDIT:
+		return sql.NullString{String: "credit", Valid: true}
+	}
+	return sql.NullString{}
+}
+
+func validateStatementFilter(f statementFilter) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(f.accountID); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidatePageSize(f.pageSize); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	if f.pageToken != "" {
+		if _, err := decodePageToken(f.pageToken); err != nil {
+			violations = append(violations, fieldViolation("page_token", err))
+		}
+	}
+
+	if f.startTime != nil && f.endTime != nil && !f.startTime.AsTime().Before(f.endTime.AsTime()) {
+		violations = append(violations, fieldViolation("end_time", errors.New("must be after start_time")))
+	}
+
+	if _, ok := pb.Direction_name[int32(f.direction)]; !ok {
+		violations = append(violations, fieldViolation("direction", errors.New("is not a valid direction")))
+	}
+
+	if f.minAmount != nil {
+		if err := val.ValidateAmount(*f.minAmount); err != nil {
+			violations = append(violations, fieldViolation("min_amount", err))
+		}
+	}
+
+	if f.maxAmount != nil {
+		if err := val.ValidateAmount(*f.maxAmount); err != nil {
+			violations = append(violations, fieldViolation("max_amount", err))
+		} else if f.minAmount != nil && *f.maxAmount < *f.minAmount {
+			violations = append(violations, fieldViolation("max_amount", errors.New("must not be less than min_amount")))
+		}
+	}
+
+	if f.counterpartyID != nil {
+		if err := val.ValidateAccountId(*f.counterpartyID); err != nil {
+			violations = append(violations, fieldViolation("counterparty_account_id", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,37 @@
+// Package interest computes the interest earned on account balances.
+//
+// Interest accrues daily on the end-of-day balance, in the account's minor
+// units, with the actual/365 fixed convention: a day earns
+// balance * annual_rate / 365, whatever the length of the year.
+// Each daily amount is rounded half to even to a whole minor unit, so small
+// balances can earn nothing on a given day. Zero and negative balances earn
+// nothing.
+package interest
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/dibrito/simple-bank/fx"
+)
+
+// DaysPerYear is the day count the annual rate is spread over.
+const DaysPerYear = 365
+
+// ParseRate parses an annual rate stored as a decimal fraction, e.g. "0.02".
+func ParseRate(s string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(s)
+	if !ok || rate.Sign() < 0 {
+		return nil, fmt.Errorf("invalid interest rate %q", s)
+	}
+	return rate, nil
+}
+
+// DailyAccrual returns the interest a balance earns in one day at annualRate.
+func DailyAccrual(balance int64, annualRate *big.Rat) int64 {
+	if balance <= 0 {
+		return 0
+	}
+	dailyRate := new(big.Rat).Quo(annualRate, big.NewRat(DaysPerYear, 1))
+	return fx.Convert(balance, dailyRate)
+}
@@ -0,0 +1,46 @@
+package interest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRate(t *testing.T) {
+	rate, err := ParseRate("0.02")
+	require.NoError(t, err)
+	require.Equal(t, "1/50", rate.String())
+
+	_, err = ParseRate("-0.01")
+	require.Error(t, err)
+
+	_, err = ParseRate("two percent")
+	require.Error(t, err)
+}
+
+func TestDailyAccrual(t *testing.T) {
+	tcs := []struct {
+		name    string
+		balance int64
+		rate    string
+		want    int64
+	}{
+		{name: "Exact", balance: 36500000, rate: "0.02", want: 2000},
+		{name: "RoundUp", balance: 1000000, rate: "0.02", want: 55},     // 54.79
+		{name: "RoundDown", balance: 1000000, rate: "0.03", want: 82},   // 82.19
+		{name: "HalfToEvenDown", balance: 18250, rate: "0.05", want: 2}, // 2.5
+		{name: "HalfToEvenUp", balance: 54750, rate: "0.05", want: 8},   // 7.5
+		{name: "TooSmall", balance: 100, rate: "0.02", want: 0},         // 0.0055
+		{name: "ZeroRate", balance: 1000000, rate: "0", want: 0},
+		{name: "Negative", balance: -1000000, rate: "0.02", want: 0},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			rate, err := ParseRate(tc.rate)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, DailyAccrual(tc.balance, rate))
+		})
+	}
+}
@@ -18,11 +18,13 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/dibrito/simple-bank/api"
+	"github.com/dibrito/simple-bank/currency"
 	db "github.com/dibrito/simple-bank/db/sqlc"
 	_ "github.com/dibrito/simple-bank/docs/statik"
 	"github.com/dibrito/simple-bank/gapi"
 	"github.com/dibrito/simple-bank/mail"
 	"github.com/dibrito/simple-bank/pb"
+	"github.com/dibrito/simple-bank/token"
 	"github.com/dibrito/simple-bank/util"
 	"github.com/dibrito/simple-bank/worker"
 
@@ -32,6 +34,7 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	_ "github.com/lib/pq"
 	"github.com/rakyll/statik/fs"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 )
 
@@ -60,15 +63,34 @@ func main() {
 	runDBMigration(config.DBMigrationPath, config.DBSource)
 
 	store := db.NewStore(conn)
+	if err := currency.Load(context.Background(), store); err != nil {
+		log.Fatal().Err(err).Msg("cannot load currencies")
+	}
+
 	// run redis
 	redisOpt := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
 	}
 
 	taskDistributer := worker.NewRedisDistributor(redisOpt)
+	revoker := newRevoker(config)
 	go runTaskProcessor(config, redisOpt, store)
-	go runGatawayServer(config, store, taskDistributer)
-	runGRPCServer(config, store, taskDistributer)
+	go runGatawayServer(config, store, taskDistributer, revoker)
+	runGRPCServer(config, store, taskDistributer, revoker)
+}
+
+// newRevoker keeps token revocations in Redis so every instance sees them,
+// or in memory if Redis can't be reached.
+func newRevoker(config util.Config) token.Revoker {
+	client := redis.NewClient(&redis.Options{
+		Addr: config.RedisAddress,
+	})
+	err := client.Ping(context.Background()).Err()
+	if err != nil {
+		log.Warn().Err(err).Msg("cannot reach redis, token revocations are kept in memory")
+		return token.NewMemoryRevoker(config.RefreshDuration)
+	}
+	return token.NewRedisRevoker(client, config.RefreshDuration)
 }
 
 func runDBMigration(migrationUrl, dbSource string) {
@@ -84,14 +106,14 @@ func runDBMigration(migrationUrl, dbSource string) {
 	log.Info().Msg("db migrated successfully!")
 }
 
-func runGRPCServer(config util.Config, store db.Store, td worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, td)
+func runGRPCServer(config util.Config, store db.Store, td worker.TaskDistributor, revoker token.Revoker) {
+	server, err := gapi.NewServer(config, store, td, revoker)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server:%v")
 	}
 
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
-	grpcServer := grpc.NewServer(grpcLogger)
+	interceptors := grpc.ChainUnaryInterceptor(gapi.GrpcLogger, gapi.AuthInterceptor(server))
+	grpcServer := grpc.NewServer(interceptors)
 
 	pb.RegisterSimpleBankServer(grpcServer, server)
 	// optinonal but  allows the gRPC client to easily explore
@@ -111,8 +133,8 @@ func runGRPCServer(config util.Config, store db.Store, td worker.TaskDistributor
 	}
 }
 
-func runGatawayServer(config util.Config, store db.Store, td worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, td)
+func runGatawayServer(config util.Config, store db.Store, td worker.TaskDistributor, revoker token.Revoker) {
+	server, err := gapi.NewServer(config, store, td, revoker)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server:%v")
 	}
@@ -125,7 +147,7 @@ func runGatawayServer(config util.Config, store db.Store, td worker.TaskDistribu
 			DiscardUnknown: true,
 		},
 	})
-	grpcMux := runtime.NewServeMux(jsonOption)
+	grpcMux := runtime.NewServeMux(jsonOption, runtime.WithIncomingHeaderMatcher(gapi.HeaderMatcher))
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -140,6 +162,9 @@ func runGatawayServer(config util.Config, store db.Store, td worker.TaskDistribu
 	}
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
+	// grpc-gateway can't take a CSV body, so the upload is served beside it
+	mux.Handle("/v1/batches/csv", gapi.BatchCSVHandler(server))
+	mux.Handle("/.well-known/jwks.json", gapi.JWKSHandler(server))
 
 	// fs := http.FileServer(http.Dir("./docs/swagger"))
 	fsStatik, err := fs.New()
@@ -163,8 +188,8 @@ func runGatawayServer(config util.Config, store db.Store, td worker.TaskDistribu
 	}
 }
 
-func runHttpServer(config util.Config, store db.Store) {
-	server, err := api.NewServer(config, store)
+func runHttpServer(config util.Config, store db.Store, revoker token.Revoker) {
+	server, err := api.NewServer(config, store, revoker)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server:%v")
 	}
@@ -189,4 +214,13 @@ func runTaskProcessor(c util.Config, redisOpt asynq.RedisClientOpt, store db.Sto
 	if err != nil {
 		log.Fatal().Err(err).Msg("start task processor")
 	}
+
+	scheduler, err := worker.NewScheduler(redisOpt)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create task scheduler")
+	}
+	err = scheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("start task scheduler")
+	}
 }
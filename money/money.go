@@ -0,0 +1,101 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dibrito/simple-bank/currency"
+)
+
+var (
+	ErrInvalidAmount       = errors.New("is not a valid decimal amount")
+	ErrTooManyDecimals     = errors.New("has more decimal places than the currency allows")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+// Money is an amount in the minor units of its currency, e.g. cents.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// Parse reads a decimal string such as "12.34" as an amount of currency code.
+func Parse(value, code string) (Money, error) {
+	c, ok := currency.Get(code)
+	if !ok {
+		return Money{}, ErrUnsupportedCurrency
+	}
+	amount, err := ParseMinor(value, c.MinorUnit)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: code}, nil
+}
+
+// String formats the amount as a decimal string, without the currency code.
+func (m Money) String() string {
+	c, ok := currency.Get(m.Currency)
+	if !ok {
+		return strconv.FormatInt(m.Amount, 10)
+	}
+	return FormatMinor(m.Amount, c.MinorUnit)
+}
+
+// ParseMinor converts a decimal string into minor units for a currency with
+// minorUnit decimal places, e.g. "12.34" with 2 is 1234.
+func ParseMinor(value string, minorUnit int32) (int64, error) {
+	s := value
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+	whole, frac, hasDot := strings.Cut(s, ".")
+	if whole == "" || (hasDot && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return 0, ErrInvalidAmount
+	}
+	if int32(len(frac)) > minorUnit {
+		return 0, ErrTooManyDecimals
+	}
+	frac += strings.Repeat("0", int(minorUnit)-len(frac))
+
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// FormatMinor renders an amount of minor units as a decimal string,
+// e.g. 1234 with 2 decimal places is "12.34".
+func FormatMinor(amount int64, minorUnit int32) string {
+	if minorUnit <= 0 {
+		return strconv.FormatInt(amount, 10)
+	}
+	sign := ""
+	u := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		// two's complement negation also handles math.MinInt64
+		u = ^u + 1
+	}
+	digits := strconv.FormatUint(u, 10)
+	if pad := int(minorUnit) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	cut := len(digits) - int(minorUnit)
+	return fmt.Sprintf("%s%s.%s", sign, digits[:cut], digits[cut:])
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
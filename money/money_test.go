@@ -0,0 +1,62 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dibrito/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMinor(t *testing.T) {
+	tcs := []struct {
+		value     string
+		minorUnit int32
+		want      int64
+		err       error
+	}{
+		{value: "12.34", minorUnit: 2, want: 1234},
+		{value: "12.3", minorUnit: 2, want: 1230},
+		{value: "12", minorUnit: 2, want: 1200},
+		{value: "0.05", minorUnit: 2, want: 5},
+		{value: "-1.50", minorUnit: 2, want: -150},
+		{value: "1500", minorUnit: 0, want: 1500},
+		{value: "1.234", minorUnit: 3, want: 1234},
+		{value: "1.234", minorUnit: 2, err: ErrTooManyDecimals},
+		{value: "1.5", minorUnit: 0, err: ErrTooManyDecimals},
+		{value: "", minorUnit: 2, err: ErrInvalidAmount},
+		{value: "1.", minorUnit: 2, err: ErrInvalidAmount},
+		{value: ".5", minorUnit: 2, err: ErrInvalidAmount},
+		{value: "1,50", minorUnit: 2, err: ErrInvalidAmount},
+		{value: "1e3", minorUnit: 2, err: ErrInvalidAmount},
+		{value: "99999999999999999999", minorUnit: 2, err: ErrInvalidAmount},
+	}
+	for _, tc := range tcs {
+		got, err := ParseMinor(tc.value, tc.minorUnit)
+		if tc.err != nil {
+			require.ErrorIs(t, err, tc.err, tc.value)
+			continue
+		}
+		require.NoError(t, err, tc.value)
+		require.Equal(t, tc.want, got, tc.value)
+	}
+}
+
+func TestFormatMinor(t *testing.T) {
+	require.Equal(t, "12.34", FormatMinor(1234, 2))
+	require.Equal(t, "0.05", FormatMinor(5, 2))
+	require.Equal(t, "0.00", FormatMinor(0, 2))
+	require.Equal(t, "-1.50", FormatMinor(-150, 2))
+	require.Equal(t, "1500", FormatMinor(1500, 0))
+	require.Equal(t, "-92233720368547758.08", FormatMinor(math.MinInt64, 2))
+}
+
+func TestMoney(t *testing.T) {
+	m, err := Parse("10.99", util.USD)
+	require.NoError(t, err)
+	require.Equal(t, Money{Amount: 1099, Currency: util.USD}, m)
+	require.Equal(t, "10.99", m.String())
+
+	_, err = Parse("10.99", "XYZ")
+	require.ErrorIs(t, err, ErrUnsupportedCurrency)
+}
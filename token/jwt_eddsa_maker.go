@@ -0,0 +1,111 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// signingMethodEdDSA signs JWTs with Ed25519, which jwt-go v3 lacks.
+type signingMethodEdDSA struct{}
+
+// SigningMethodEdDSA is the EdDSA signing method of RFC 8037.
+var SigningMethodEdDSA = &signingMethodEdDSA{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEdDSA.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+func (m *signingMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+func (m *signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+	return jwt.EncodeSegment(ed25519.Sign(private, []byte(signingString))), nil
+}
+
+func (m *signingMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	public, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(public, []byte(signingString), sig) {
+		return jwt.ErrSignatureInvalid
+	}
+	return nil
+}
+
+// EdDSAJWTMaker is the JWT maker signing with the Ed25519 keys of a KeySet.
+// The kid header names the key that signed the token.
+type EdDSAJWTMaker struct {
+	keys *KeySet
+}
+
+// NewEdDSAJWTMaker creates a new EdDSAJWTMaker
+func NewEdDSAJWTMaker(keys *KeySet) (Maker, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("missing signing keys")
+	}
+	return &EdDSAJWTMaker{keys}, nil
+}
+
+// CreateToken creates and assing a token for a user
+func (maker *EdDSAJWTMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	jwtToken := jwt.NewWithClaims(SigningMethodEdDSA, payload)
+	jwtToken.Header["kid"] = maker.keys.activeID
+	token, err := jwtToken.SignedString(maker.keys.active)
+	return token, payload, err
+}
+
+// VerifyToken check if a token is valid
+func (maker *EdDSAJWTMaker) VerifyToken(token string) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		if token.Method != SigningMethodEdDSA {
+			return nil, ErrInvalidToken
+		}
+		kid, _ := token.Header["kid"].(string)
+		public, ok := maker.keys.publicKey(kid)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		return public, nil
+	}
+
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	if err != nil {
+		verr, ok := err.(*jwt.ValidationError)
+		if ok && errors.Is(verr.Inner, ErrExpiredToken) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	payload, ok := jwtToken.Claims.(*Payload)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	return payload, nil
+}
+
+// Keys returns the keys the tokens are signed with.
+func (maker *EdDSAJWTMaker) Keys() *KeySet {
+	return maker.keys
+}
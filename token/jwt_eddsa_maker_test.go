@@ -0,0 +1,92 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEdDSAJWTMaker(t *testing.T, privateKey string, verificationKeys ...string) Maker {
+	keys, err := NewKeySet(privateKey, verificationKeys)
+	require.NoError(t, err)
+	maker, err := NewEdDSAJWTMaker(keys)
+	require.NoError(t, err)
+	return maker
+}
+
+func TestEdDSAJWTMaker(t *testing.T) {
+	private, _ := randomKey(t)
+	maker := newTestEdDSAJWTMaker(t, private)
+
+	user := util.RandomOwner()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := time.Now().Add(duration)
+
+	token, payload, err := maker.CreateToken(user, util.RoleCustomer, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	p, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, p)
+
+	require.Equal(t, payload.ID, p.ID)
+	require.Equal(t, user, p.Username)
+	require.Equal(t, util.RoleCustomer, p.Role)
+	require.WithinDuration(t, issuedAt, p.IssuedAt, time.Minute)
+	require.WithinDuration(t, expiredAt, p.ExpireAt, time.Minute)
+}
+
+func TestEdDSAJWTMakerKeyRotation(t *testing.T) {
+	oldPrivate, oldPublic := randomKey(t)
+	private, _ := randomKey(t)
+
+	oldMaker := newTestEdDSAJWTMaker(t, oldPrivate)
+	token, _, err := oldMaker.CreateToken(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+
+	// the retired key still verifies its tokens
+	maker := newTestEdDSAJWTMaker(t, private, oldPublic)
+	_, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+
+	// once it is dropped they are invalid
+	maker = newTestEdDSAJWTMaker(t, private)
+	_, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
+
+func TestExpiredEdDSAJWTToken(t *testing.T) {
+	private, _ := randomKey(t)
+	maker := newTestEdDSAJWTMaker(t, private)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.RoleCustomer, -time.Minute)
+	require.NoError(t, err)
+
+	p, err := maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, p)
+}
+
+func TestInvalidEdDSAJWTTokenAlgHS256(t *testing.T) {
+	private, public := randomKey(t)
+	maker := newTestEdDSAJWTMaker(t, private)
+
+	// a token signed with the public key as an HMAC secret
+	payload, err := NewPayload(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	jwtToken.Header["kid"] = maker.(*EdDSAJWTMaker).keys.activeID
+	token, err := jwtToken.SignedString([]byte(public))
+	require.NoError(t, err)
+
+	p, err := maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, p)
+}
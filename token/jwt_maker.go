@@ -23,8 +23,8 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 }
 
 // CreateToken creates and assing a token for a user
-func (j *JWTMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
-	p, err := NewPayload(username, duration)
+func (j *JWTMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	p, err := NewPayload(username, role, duration)
 	if err != nil {
 		return "", p, err
 	}
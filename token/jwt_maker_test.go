@@ -21,7 +21,7 @@ func TestJWTMaker(t *testing.T) {
 	expiredAt := time.Now().Add(duration)
 
 	// create token
-	token, payload, err := maker.CreateToken(user, duration)
+	token, payload, err := maker.CreateToken(user, util.RoleCustomer, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
@@ -34,6 +34,7 @@ func TestJWTMaker(t *testing.T) {
 	// assert payload
 	require.NotZero(t, p.ID)
 	require.Equal(t, user, p.Username)
+	require.Equal(t, util.RoleCustomer, p.Role)
 	require.WithinDuration(t, issuedAt, p.IssuedAt, time.Minute)
 	require.WithinDuration(t, expiredAt, p.ExpireAt, time.Minute)
 }
@@ -44,7 +45,7 @@ func TestExpiredJWTToken(t *testing.T) {
 	require.NoError(t, err)
 
 	// create token
-	token, payload, err := maker.CreateToken(util.RandomOwner(), -time.Minute)
+	token, payload, err := maker.CreateToken(util.RandomOwner(), util.RoleCustomer, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
@@ -57,7 +58,7 @@ func TestExpiredJWTToken(t *testing.T) {
 
 func TesInvalidJWTTokenAlgNone(t *testing.T) {
 	// create payload
-	payload, err := NewPayload(util.RandomOwner(), time.Minute)
+	payload, err := NewPayload(util.RandomOwner(), util.RoleCustomer, time.Minute)
 	require.NoError(t, err)
 
 	// create unsafe token
@@ -0,0 +1,103 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// KeySet holds the Ed25519 keys of the asymmetric makers. The active key
+// signs new tokens; it and the verification keys of retired signing keys
+// verify them, so rotating the active key does not log anyone out.
+type KeySet struct {
+	activeID string
+	active   ed25519.PrivateKey
+	// ids keeps the key ids in order, the active one first.
+	ids    []string
+	public map[string]ed25519.PublicKey
+}
+
+// NewKeySet creates a new KeySet from the base64 encoded seed of the active
+// private key and the base64 encoded public keys still accepted.
+func NewKeySet(privateKey string, verificationKeys []string) (*KeySet, error) {
+	seed, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid private key size: must be exactly %d bytes", ed25519.SeedSize)
+	}
+
+	active := ed25519.NewKeyFromSeed(seed)
+	keys := &KeySet{
+		active: active,
+		public: make(map[string]ed25519.PublicKey),
+	}
+	keys.activeID = keys.add(active.Public().(ed25519.PublicKey))
+
+	for _, key := range verificationKeys {
+		public, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification key: %w", err)
+		}
+		if len(public) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid verification key size: must be exactly %d bytes", ed25519.PublicKeySize)
+		}
+		keys.add(public)
+	}
+	return keys, nil
+}
+
+// add adds public to the set and returns its id.
+func (k *KeySet) add(public ed25519.PublicKey) string {
+	kid := KeyID(public)
+	if _, ok := k.public[kid]; !ok {
+		k.ids = append(k.ids, kid)
+		k.public[kid] = public
+	}
+	return kid
+}
+
+// publicKey returns the public key with id kid.
+func (k *KeySet) publicKey(kid string) (ed25519.PublicKey, bool) {
+	public, ok := k.public[kid]
+	return public, ok
+}
+
+// KeyID returns the id of public, its RFC 7638 JWK thumbprint.
+func KeyID(public ed25519.PublicKey) string {
+	// the members in lexicographic order, without whitespace
+	thumbprint := fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, base64.RawURLEncoding.EncodeToString(public))
+	sum := sha256.Sum256([]byte(thumbprint))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// JSONWebKey is an Ed25519 public key in the JWK format of RFC 8037.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+}
+
+// JSONWebKeySet is the document served at /.well-known/jwks.json.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public keys of the set, the active one first.
+func (k *KeySet) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, kid := range k.ids {
+		set.Keys = append(set.Keys, JSONWebKey{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(k.public[kid]),
+			Kid: kid,
+			Use: "sig",
+		})
+	}
+	return set
+}
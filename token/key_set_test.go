@@ -0,0 +1,61 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// randomKey returns the base64 encoded seed of a new Ed25519 key and its
+// base64 encoded public key.
+func randomKey(t *testing.T) (privateKey, publicKey string) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(private.Seed()), base64.StdEncoding.EncodeToString(public)
+}
+
+func TestNewKeySet(t *testing.T) {
+	oldPrivate, oldPublic := randomKey(t)
+	private, public := randomKey(t)
+
+	keys, err := NewKeySet(private, []string{oldPublic, public})
+	require.NoError(t, err)
+
+	// the active key is listed once, first
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	require.Equal(t, keys.activeID, jwks.Keys[0].Kid)
+	for _, key := range jwks.Keys {
+		require.Equal(t, "OKP", key.Kty)
+		require.Equal(t, "Ed25519", key.Crv)
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		require.NoError(t, err)
+		require.Equal(t, key.Kid, KeyID(x))
+	}
+
+	oldKeys, err := NewKeySet(oldPrivate, nil)
+	require.NoError(t, err)
+	require.Equal(t, oldKeys.activeID, jwks.Keys[1].Kid)
+	require.NotEqual(t, jwks.Keys[0].Kid, jwks.Keys[1].Kid)
+}
+
+func TestNewKeySetInvalid(t *testing.T) {
+	private, _ := randomKey(t)
+
+	_, err := NewKeySet("not base64!", nil)
+	require.Error(t, err)
+	_, err = NewKeySet(base64.StdEncoding.EncodeToString([]byte("short")), nil)
+	require.Error(t, err)
+	_, err = NewKeySet(private, []string{base64.StdEncoding.EncodeToString([]byte("short"))})
+	require.Error(t, err)
+}
+
+func TestKeyID(t *testing.T) {
+	// RFC 8037 appendix A.3
+	x, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	require.NoError(t, err)
+	require.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", KeyID(x))
+}
@@ -1,11 +1,44 @@
 package token
 
-import "time"
+import (
+	"time"
+
+	"github.com/dibrito/simple-bank/util"
+)
 
 // Maker will manager tokens
 type Maker interface {
 	// CreateToken creates and assing a token for a user
-	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+	CreateToken(username string, role string, duration time.Duration) (string, *Payload, error)
 	// VerifyToken check if a token is valid
 	VerifyToken(token string) (*Payload, error)
 }
+
+// PublicKeyMaker is a Maker whose tokens anyone can verify with the public
+// keys of its KeySet.
+type PublicKeyMaker interface {
+	Maker
+	// Keys returns the keys the tokens are signed with
+	Keys() *KeySet
+}
+
+// NewMaker creates the Maker configured by config: tokens are signed with
+// TOKEN_PRIVATE_KEY if it is set, otherwise with TOKEN_SYMMETRIC_KEY.
+// TOKEN_FORMAT picks paseto, the default, or jwt.
+func NewMaker(config util.Config) (Maker, error) {
+	if config.TokenPrivateKey == "" {
+		if config.TokenFormat == util.TokenFormatJWT {
+			return NewJWTMaker(config.TokenSymmetricKey)
+		}
+		return NewPasetoMaker(config.TokenSymmetricKey)
+	}
+
+	keys, err := NewKeySet(config.TokenPrivateKey, config.TokenVerificationKeys)
+	if err != nil {
+		return nil, err
+	}
+	if config.TokenFormat == util.TokenFormatJWT {
+		return NewEdDSAJWTMaker(keys)
+	}
+	return NewPasetoPublicMaker(keys)
+}
@@ -0,0 +1,81 @@
+package token
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryRevoker keeps revocations in process memory. They are lost on
+// restart and not shared between instances, so it is a fallback for when
+// Redis is not available.
+type MemoryRevoker struct {
+	mu sync.Mutex
+	// maxTokenDuration is how long a user watermark is kept: by then every
+	// token it denies has expired anyway.
+	maxTokenDuration time.Duration
+	// tokens maps a revoked token ID to its expiry.
+	tokens map[string]time.Time
+	// watermarks maps a username to the time its tokens must be issued after.
+	watermarks map[string]time.Time
+}
+
+// NewMemoryRevoker creates a new MemoryRevoker for tokens that last at most
+// maxTokenDuration.
+func NewMemoryRevoker(maxTokenDuration time.Duration) *MemoryRevoker {
+	return &MemoryRevoker{
+		maxTokenDuration: maxTokenDuration,
+		tokens:           make(map[string]time.Time),
+		watermarks:       make(map[string]time.Time),
+	}
+}
+
+// RevokeToken denies the token of payload until it expires.
+func (r *MemoryRevoker) RevokeToken(ctx context.Context, payload *Payload) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.purge(time.Now())
+	r.tokens[payload.ID.String()] = payload.ExpireAt
+	return nil
+}
+
+// RevokeUserTokens denies every token of username issued before t.
+func (r *MemoryRevoker) RevokeUserTokens(ctx context.Context, username string, t time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.purge(time.Now())
+	if t.After(r.watermarks[username]) {
+		r.watermarks[username] = t
+	}
+	return nil
+}
+
+// CheckToken returns ErrRevokedToken if the token of payload was revoked.
+func (r *MemoryRevoker) CheckToken(ctx context.Context, payload *Payload) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tokens[payload.ID.String()]; ok {
+		return ErrRevokedToken
+	}
+	if payload.IssuedAt.Before(r.watermarks[payload.Username]) {
+		return ErrRevokedToken
+	}
+	return nil
+}
+
+// purge drops the revocations that no longer deny an unexpired token.
+func (r *MemoryRevoker) purge(now time.Time) {
+	for id, expireAt := range r.tokens {
+		if now.After(expireAt) {
+			delete(r.tokens, id)
+		}
+	}
+	for username, t := range r.watermarks {
+		if now.After(t.Add(r.maxTokenDuration)) {
+			delete(r.watermarks, username)
+		}
+	}
+}
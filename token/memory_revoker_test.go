@@ -0,0 +1,69 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dibrito/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRevokerRevokeToken(t *testing.T) {
+	revoker := NewMemoryRevoker(time.Hour)
+	ctx := context.Background()
+
+	payload, err := NewPayload(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+	other, err := NewPayload(payload.Username, util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, revoker.CheckToken(ctx, payload))
+
+	require.NoError(t, revoker.RevokeToken(ctx, payload))
+	require.ErrorIs(t, revoker.CheckToken(ctx, payload), ErrRevokedToken)
+	// other tokens of the user are still valid
+	require.NoError(t, revoker.CheckToken(ctx, other))
+}
+
+func TestMemoryRevokerRevokeUserTokens(t *testing.T) {
+	revoker := NewMemoryRevoker(time.Hour)
+	ctx := context.Background()
+
+	before, err := NewPayload(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+	otherUser, err := NewPayload(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+
+	watermark := time.Now()
+	require.NoError(t, revoker.RevokeUserTokens(ctx, before.Username, watermark))
+
+	after, err := NewPayload(before.Username, util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, revoker.CheckToken(ctx, before), ErrRevokedToken)
+	require.NoError(t, revoker.CheckToken(ctx, after))
+	require.NoError(t, revoker.CheckToken(ctx, otherUser))
+
+	// an older watermark does not lower the current one
+	require.NoError(t, revoker.RevokeUserTokens(ctx, before.Username, watermark.Add(-time.Hour)))
+	require.ErrorIs(t, revoker.CheckToken(ctx, before), ErrRevokedToken)
+}
+
+func TestMemoryRevokerPurge(t *testing.T) {
+	revoker := NewMemoryRevoker(time.Minute)
+	ctx := context.Background()
+
+	expired, err := NewPayload(util.RandomOwner(), util.RoleCustomer, -time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, revoker.RevokeToken(ctx, expired))
+	require.NoError(t, revoker.RevokeUserTokens(ctx, expired.Username, time.Now().Add(-time.Hour)))
+
+	// the next write drops revocations that can no longer deny a valid token
+	payload, err := NewPayload(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, revoker.RevokeToken(ctx, payload))
+
+	require.Len(t, revoker.tokens, 1)
+	require.Empty(t, revoker.watermarks)
+}
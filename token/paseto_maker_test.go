@@ -22,7 +22,7 @@ func TestPasetoMaker(t *testing.T) {
 	expiredAt := time.Now().Add(duration)
 
 	// create token
-	token, payload, err := maker.CreateToken(user, duration)
+	token, payload, err := maker.CreateToken(user, util.RoleCustomer, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
@@ -35,6 +35,7 @@ func TestPasetoMaker(t *testing.T) {
 	// assert payload
 	require.NotZero(t, p.ID)
 	require.Equal(t, user, p.Username)
+	require.Equal(t, util.RoleCustomer, p.Role)
 	require.WithinDuration(t, issuedAt, p.IssuedAt, time.Minute)
 	require.WithinDuration(t, expiredAt, p.ExpireAt, time.Minute)
 }
@@ -45,7 +46,7 @@ func TestExpiredPasetoToken(t *testing.T) {
 	require.NoError(t, err)
 
 	// create token
-	token, payload, err := maker.CreateToken(util.RandomOwner(), -time.Minute)
+	token, payload, err := maker.CreateToken(util.RandomOwner(), util.RoleCustomer, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
@@ -63,7 +64,7 @@ func TestInvalidPasetoToken(t *testing.T) {
 	require.Empty(t, maker)
 
 	// // create token
-	// token, err := maker.CreateToken(util.RandomOwner(), time.Minute)
+	// token, err := maker.CreateToken(util.RandomOwner(), util.RoleCustomer, time.Minute)
 	// require.NoError(t, err)
 	// require.NotEmpty(t, token)
 
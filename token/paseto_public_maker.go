@@ -0,0 +1,155 @@
+package token
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pasetoV4PublicHeader prefixes every v4.public token.
+const pasetoV4PublicHeader = "v4.public."
+
+// pasetoFooter is the footer of our v4.public tokens, it names the key that
+// signed the token.
+type pasetoFooter struct {
+	Kid string `json:"kid"`
+}
+
+// PasetoPublicMaker is the PASETO v4.public maker signing with the Ed25519
+// keys of a KeySet.
+type PasetoPublicMaker struct {
+	keys *KeySet
+}
+
+// NewPasetoPublicMaker creates a new PasetoPublicMaker
+func NewPasetoPublicMaker(keys *KeySet) (Maker, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("missing signing keys")
+	}
+	return &PasetoPublicMaker{keys}, nil
+}
+
+// CreateToken creates and assing a token for a user
+func (maker *PasetoPublicMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", payload, err
+	}
+	footer, err := json.Marshal(pasetoFooter{Kid: maker.keys.activeID})
+	if err != nil {
+		return "", payload, err
+	}
+
+	return signV4Public(maker.keys.active, message, footer, nil), payload, nil
+}
+
+// VerifyToken check if a token is valid
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	message, sig, footer, err := parseV4Public(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// the footer is read before it is verified only to pick the key
+	var f pasetoFooter
+	if err := json.Unmarshal(footer, &f); err != nil {
+		return nil, ErrInvalidToken
+	}
+	public, ok := maker.keys.publicKey(f.Kid)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	if !verifyV4Public(public, message, sig, footer, nil) {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{}
+	if err := json.Unmarshal(message, payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// Keys returns the keys the tokens are signed with.
+func (maker *PasetoPublicMaker) Keys() *KeySet {
+	return maker.keys
+}
+
+// signV4Public signs message with key into a v4.public token. The footer is
+// sent with the token, the implicit assertion must be given again to verify it.
+func signV4Public(key ed25519.PrivateKey, message, footer, implicit []byte) string {
+	sig := ed25519.Sign(key, pae([]byte(pasetoV4PublicHeader), message, footer, implicit))
+	body := append(append([]byte{}, message...), sig...)
+	token := pasetoV4PublicHeader + base64.RawURLEncoding.EncodeToString(body)
+	if len(footer) > 0 {
+		token += "." + base64.RawURLEncoding.EncodeToString(footer)
+	}
+	return token
+}
+
+// parseV4Public splits a v4.public token into its message, signature and
+// footer, without verifying them.
+func parseV4Public(token string) (message, sig, footer []byte, err error) {
+	if !strings.HasPrefix(token, pasetoV4PublicHeader) {
+		return nil, nil, nil, ErrInvalidToken
+	}
+	parts := strings.Split(strings.TrimPrefix(token, pasetoV4PublicHeader), ".")
+	if len(parts) > 2 {
+		return nil, nil, nil, ErrInvalidToken
+	}
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(body) < ed25519.SignatureSize {
+		return nil, nil, nil, ErrInvalidToken
+	}
+	if len(parts) == 2 {
+		footer, err = base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, nil, nil, ErrInvalidToken
+		}
+	}
+
+	message, sig = body[:len(body)-ed25519.SignatureSize], body[len(body)-ed25519.SignatureSize:]
+	return message, sig, footer, nil
+}
+
+// verifyV4Public reports whether sig signs message, footer and the implicit
+// assertion with key.
+func verifyV4Public(key ed25519.PublicKey, message, sig, footer, implicit []byte) bool {
+	return ed25519.Verify(key, pae([]byte(pasetoV4PublicHeader), message, footer, implicit), sig)
+}
+
+// pae is the pre-authentication encoding of the PASETO spec: the number of
+// pieces, then each piece after its length, as little endian 64 bit
+// integers with the top bit cleared.
+func pae(pieces ...[]byte) []byte {
+	var buf bytes.Buffer
+	le64 := func(n int) {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], uint64(n)&^(1<<63))
+		buf.Write(b[:])
+	}
+
+	le64(len(pieces))
+	for _, piece := range pieces {
+		le64(len(piece))
+		buf.Write(piece)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,155 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dibrito/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPasetoPublicMaker(t *testing.T, privateKey string, verificationKeys ...string) Maker {
+	keys, err := NewKeySet(privateKey, verificationKeys)
+	require.NoError(t, err)
+	maker, err := NewPasetoPublicMaker(keys)
+	require.NoError(t, err)
+	return maker
+}
+
+func TestPasetoPublicMaker(t *testing.T) {
+	private, _ := randomKey(t)
+	maker := newTestPasetoPublicMaker(t, private)
+
+	user := util.RandomOwner()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := time.Now().Add(duration)
+
+	token, payload, err := maker.CreateToken(user, util.RoleCustomer, duration)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, "v4.public."))
+	require.NotEmpty(t, payload)
+
+	p, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, p)
+
+	require.Equal(t, payload.ID, p.ID)
+	require.Equal(t, user, p.Username)
+	require.Equal(t, util.RoleCustomer, p.Role)
+	require.WithinDuration(t, issuedAt, p.IssuedAt, time.Minute)
+	require.WithinDuration(t, expiredAt, p.ExpireAt, time.Minute)
+}
+
+func TestPasetoPublicMakerKeyRotation(t *testing.T) {
+	oldPrivate, oldPublic := randomKey(t)
+	private, _ := randomKey(t)
+
+	oldMaker := newTestPasetoPublicMaker(t, oldPrivate)
+	token, _, err := oldMaker.CreateToken(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+
+	// the retired key still verifies its tokens
+	maker := newTestPasetoPublicMaker(t, private, oldPublic)
+	_, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+
+	// once it is dropped they are invalid
+	maker = newTestPasetoPublicMaker(t, private)
+	_, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
+
+func TestExpiredPasetoPublicToken(t *testing.T) {
+	private, _ := randomKey(t)
+	maker := newTestPasetoPublicMaker(t, private)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.RoleCustomer, -time.Minute)
+	require.NoError(t, err)
+
+	p, err := maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, p)
+}
+
+func TestInvalidPasetoPublicToken(t *testing.T) {
+	private, _ := randomKey(t)
+	maker := newTestPasetoPublicMaker(t, private)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	for _, invalid := range []string{
+		"",
+		"v2.public." + parts[2] + "." + parts[3],
+		"v4.public." + parts[2],
+		// a footer naming another key
+		"v4.public." + parts[2] + ".eyJraWQiOiJvdGhlciJ9",
+		// a flipped bit in the message
+		"v4.public." + strings.Replace(parts[2], parts[2][:1], string(parts[2][0]^1), 1) + "." + parts[3],
+	} {
+		p, err := maker.VerifyToken(invalid)
+		require.EqualError(t, err, ErrInvalidToken.Error())
+		require.Nil(t, p)
+	}
+}
+
+func TestPAE(t *testing.T) {
+	// test vectors of the PASETO spec
+	require.Equal(t, []byte("\x00\x00\x00\x00\x00\x00\x00\x00"), pae())
+	require.Equal(t, []byte("\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"), pae([]byte("")))
+	require.Equal(t, []byte("\x01\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00test"), pae([]byte("test")))
+}
+
+func TestPasetoV4PublicVectors(t *testing.T) {
+	// test vectors 4-S-1 to 4-S-3 of the PASETO spec
+	secret, err := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774" +
+		"1eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	require.NoError(t, err)
+	public, err := hex.DecodeString("1eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	require.NoError(t, err)
+	message := `{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`
+	footer := `{"kid":"zVhMiPBP9fRf2snEcT7gFTioeA9COcNy9DfgL1W60haN"}`
+
+	for _, tc := range []struct {
+		name     string
+		token    string
+		footer   string
+		implicit string
+	}{
+		{
+			name:  "4-S-1",
+			token: "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA",
+		},
+		{
+			name:   "4-S-2",
+			token:  "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9v3Jt8mx_TdM2ceTGoqwrh4yDFn0XsHvvV_D0DtwQxVrJEBMl0F2caAdgnpKlt4p7xBnx1HcO-SPo8FPp214HDw.eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+			footer: footer,
+		},
+		{
+			name:     "4-S-3",
+			token:    "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9NPWciuD3d0o5eXJXG5pJy-DiVEoyPYWs1YSTwWHNJq6DZD3je5gf-0M4JR9ipdUSJbIovzmBECeaWmaqcaP0DQ.eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+			footer:   footer,
+			implicit: `{"test-vector":"4-S-3"}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			token := signV4Public(ed25519.PrivateKey(secret), []byte(message), []byte(tc.footer), []byte(tc.implicit))
+			require.Equal(t, tc.token, token)
+
+			m, sig, f, err := parseV4Public(tc.token)
+			require.NoError(t, err)
+			require.Equal(t, message, string(m))
+			require.Equal(t, tc.footer, string(f))
+			require.True(t, verifyV4Public(ed25519.PublicKey(public), m, sig, f, []byte(tc.implicit)))
+
+			// the signature covers the implicit assertion
+			require.False(t, verifyV4Public(ed25519.PublicKey(public), m, sig, f, []byte("other")))
+		})
+	}
+}
@@ -17,12 +17,13 @@ var (
 type Payload struct {
 	ID       uuid.UUID `json:"id"`
 	Username string    `json:"username"`
+	Role     string    `json:"role"`
 	IssuedAt time.Time `json:"issuedAt"`
 	ExpireAt time.Time `json:"expiredAt"`
 }
 
-// NewPayload creates a new token payload with the given username and duration
-func NewPayload(username string, duration time.Duration) (*Payload, error) {
+// NewPayload creates a new token payload with the given username, role and duration
+func NewPayload(username string, role string, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -31,6 +32,7 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	payload := &Payload{
 		ID:       tokenID,
 		Username: username,
+		Role:     role,
 		IssuedAt: time.Now(),
 		ExpireAt: time.Now().Add(duration),
 	}
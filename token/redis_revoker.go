@@ -0,0 +1,85 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	revokedTokenKeyPrefix      = "revoked_token:"
+	tokensIssuedAfterKeyPrefix = "tokens_issued_after:"
+)
+
+// raiseWatermark sets the watermark KEYS[1] to ARGV[1], unless it already
+// holds a later time, to expire after ARGV[2] milliseconds. The times are
+// compared as decimal strings, Lua numbers lose the nanoseconds.
+var raiseWatermark = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current and (#current > #ARGV[1] or (#current == #ARGV[1] and current >= ARGV[1])) then
+  return 0
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return 1
+`)
+
+// RedisRevoker keeps revocations in Redis, shared by every instance. Each key
+// expires with the last token it can deny.
+type RedisRevoker struct {
+	client *redis.Client
+	// maxTokenDuration is how long a user watermark is kept.
+	maxTokenDuration time.Duration
+}
+
+// NewRedisRevoker creates a new RedisRevoker for tokens that last at most
+// maxTokenDuration.
+func NewRedisRevoker(client *redis.Client, maxTokenDuration time.Duration) *RedisRevoker {
+	return &RedisRevoker{
+		client:           client,
+		maxTokenDuration: maxTokenDuration,
+	}
+}
+
+// RevokeToken denies the token of payload until it expires.
+func (r *RedisRevoker) RevokeToken(ctx context.Context, payload *Payload) error {
+	ttl := time.Until(payload.ExpireAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.client.Set(ctx, revokedTokenKeyPrefix+payload.ID.String(), 1, ttl).Err()
+}
+
+// RevokeUserTokens denies every token of username issued before t.
+// An earlier t than the current watermark changes nothing.
+func (r *RedisRevoker) RevokeUserTokens(ctx context.Context, username string, t time.Time) error {
+	key := tokensIssuedAfterKeyPrefix + username
+	return raiseWatermark.Run(ctx, r.client, []string{key}, t.UnixNano(), r.maxTokenDuration.Milliseconds()).Err()
+}
+
+// CheckToken returns ErrRevokedToken if the token of payload was revoked.
+func (r *RedisRevoker) CheckToken(ctx context.Context, payload *Payload) error {
+	values, err := r.client.MGet(ctx,
+		revokedTokenKeyPrefix+payload.ID.String(),
+		tokensIssuedAfterKeyPrefix+payload.Username,
+	).Result()
+	if err != nil {
+		return fmt.Errorf("check token revocation:%w", err)
+	}
+
+	if values[0] != nil {
+		return ErrRevokedToken
+	}
+	if values[1] != nil {
+		watermark, err := strconv.ParseInt(values[1].(string), 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse token watermark:%w", err)
+		}
+		if payload.IssuedAt.UnixNano() < watermark {
+			return ErrRevokedToken
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisRevoker(t *testing.T, maxTokenDuration time.Duration) (*RedisRevoker, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisRevoker(client, maxTokenDuration), server
+}
+
+func TestRedisRevokerRevokeToken(t *testing.T) {
+	revoker, server := newTestRedisRevoker(t, time.Hour)
+	ctx := context.Background()
+
+	payload, err := NewPayload(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+	other, err := NewPayload(payload.Username, util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, revoker.CheckToken(ctx, payload))
+
+	require.NoError(t, revoker.RevokeToken(ctx, payload))
+	require.ErrorIs(t, revoker.CheckToken(ctx, payload), ErrRevokedToken)
+	// other tokens of the user are still valid
+	require.NoError(t, revoker.CheckToken(ctx, other))
+
+	// the revocation is dropped once the token expires
+	server.FastForward(time.Minute)
+	require.False(t, server.Exists(revokedTokenKeyPrefix+payload.ID.String()))
+}
+
+func TestRedisRevokerRevokeUserTokens(t *testing.T) {
+	revoker, server := newTestRedisRevoker(t, time.Hour)
+	ctx := context.Background()
+
+	before, err := NewPayload(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+	otherUser, err := NewPayload(util.RandomOwner(), util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+
+	watermark := time.Now()
+	require.NoError(t, revoker.RevokeUserTokens(ctx, before.Username, watermark))
+
+	after, err := NewPayload(before.Username, util.RoleCustomer, time.Minute)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, revoker.CheckToken(ctx, before), ErrRevokedToken)
+	require.NoError(t, revoker.CheckToken(ctx, after))
+	require.NoError(t, revoker.CheckToken(ctx, otherUser))
+
+	// an older watermark does not lower the current one
+	require.NoError(t, revoker.RevokeUserTokens(ctx, before.Username, watermark.Add(-time.Hour)))
+	require.ErrorIs(t, revoker.CheckToken(ctx, before), ErrRevokedToken)
+
+	// a later one raises it
+	require.NoError(t, revoker.RevokeUserTokens(ctx, before.Username, time.Now().Add(time.Second)))
+	require.ErrorIs(t, revoker.CheckToken(ctx, after), ErrRevokedToken)
+
+	// the watermark is dropped once every token it denies has expired
+	server.FastForward(time.Hour)
+	require.False(t, server.Exists(tokensIssuedAfterKeyPrefix+before.Username))
+}
@@ -0,0 +1,22 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrRevokedToken is returned for a token revoked before it expired.
+var ErrRevokedToken = errors.New("token has been revoked")
+
+// Revoker keeps track of the tokens revoked before they expire, since
+// VerifyToken alone accepts any token until then.
+type Revoker interface {
+	// RevokeToken denies the token of payload until it expires.
+	RevokeToken(ctx context.Context, payload *Payload) error
+	// RevokeUserTokens denies every token of username issued before t.
+	RevokeUserTokens(ctx context.Context, username string, t time.Time) error
+	// CheckToken returns ErrRevokedToken if the token of payload was revoked,
+	// by its ID or by the watermark of its user.
+	CheckToken(ctx context.Context, payload *Payload) error
+}
@@ -6,6 +6,12 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Token formats of TOKEN_FORMAT
+const (
+	TokenFormatPaseto = "paseto"
+	TokenFormatJWT    = "jwt"
+)
+
 // Config stores all configuration of the application.
 // The values are read by viper from a config file or environment variable.
 type Config struct {
@@ -22,6 +28,22 @@ type Config struct {
 	EmailSenderAddress  string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
 	EmailSenderPassword string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
 	RedisAddress        string        `mapstructure:"REDIS_ADDRESS"`
+	FXRatesFile         string        `mapstructure:"FX_RATES_FILE"`
+	FXSpread            string        `mapstructure:"FX_SPREAD"`
+	FXQuoteDuration     time.Duration `mapstructure:"FX_QUOTE_DURATION"`
+	HoldDuration        time.Duration `mapstructure:"HOLD_DURATION"`
+	// transfers to a payee saved less than PayeeCoolingOffPeriod ago may not
+	// exceed PayeeCoolingOffLimit, in minor units of the debited currency
+	PayeeCoolingOffPeriod time.Duration `mapstructure:"PAYEE_COOLING_OFF_PERIOD"`
+	PayeeCoolingOffLimit  int64         `mapstructure:"PAYEE_COOLING_OFF_LIMIT"`
+	// TokenPrivateKey is the base64 encoded seed of the Ed25519 key signing
+	// new tokens instead of TokenSymmetricKey. TokenVerificationKeys are the
+	// base64 encoded public keys of the keys it replaced, accepted until
+	// their tokens expire.
+	TokenPrivateKey       string   `mapstructure:"TOKEN_PRIVATE_KEY"`
+	TokenVerificationKeys []string `mapstructure:"TOKEN_VERIFICATION_KEYS"`
+	// TokenFormat is TokenFormatPaseto, the default, or TokenFormatJWT
+	TokenFormat string `mapstructure:"TOKEN_FORMAT"`
 }
 
 // LoadConfig read configuration from a file or enviromental variables.
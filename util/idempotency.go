@@ -0,0 +1,22 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// internalIdempotencyKeyPrefix starts the idempotency keys of the transfers
+// the bank makes on its own. Clients may not use it.
+const internalIdempotencyKeyPrefix = "internal:"
+
+// InternalIdempotencyKey returns an idempotency key formatted like
+// fmt.Sprintf, in the namespace reserved for the bank.
+func InternalIdempotencyKey(format string, a ...interface{}) string {
+	return internalIdempotencyKeyPrefix + fmt.Sprintf(format, a...)
+}
+
+// IsInternalIdempotencyKey reports whether key is in the namespace reserved
+// for the bank.
+func IsInternalIdempotencyKey(key string) bool {
+	return strings.HasPrefix(key, internalIdempotencyKeyPrefix)
+}
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaskFullName keeps the first letter of each word of name and replaces the
+// rest with asterisks, e.g. "John Smith" becomes "J*** S****".
+func MaskFullName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		first, size := utf8.DecodeRuneInString(word)
+		words[i] = string(first) + strings.Repeat("*", utf8.RuneCountInString(word[size:]))
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,15 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskFullName(t *testing.T) {
+	require.Equal(t, "J*** S****", MaskFullName("John Smith"))
+	require.Equal(t, "J*** S****", MaskFullName("  John   Smith "))
+	require.Equal(t, "J", MaskFullName("J"))
+	require.Equal(t, "É****", MaskFullName("Émile"))
+	require.Equal(t, "", MaskFullName(""))
+}
@@ -0,0 +1,13 @@
+package util
+
+// Roles of the users, carried in their access tokens.
+const (
+	// RoleCustomer owns accounts and moves money, the role of every new user.
+	RoleCustomer = "customer"
+	// RoleSupport helps customers, managing only its own user and sessions.
+	RoleSupport = "support"
+	// RoleAdmin runs the bank.
+	RoleAdmin = "admin"
+	// RoleAuditor reads the books without changing them.
+	RoleAuditor = "auditor"
+)
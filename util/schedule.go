@@ -0,0 +1,19 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// NextRun returns the first activation of schedule strictly after the given time.
+// schedule is a standard 5 field cron spec ("0 9 1 * *") or a descriptor
+// such as "@monthly" or "@every 168h".
+func NextRun(schedule string, after time.Time) (time.Time, error) {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q:%w", schedule, err)
+	}
+	return sched.Next(after), nil
+}
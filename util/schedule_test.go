@@ -0,0 +1,27 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextRun(t *testing.T) {
+	after := time.Date(2030, time.January, 15, 10, 30, 0, 0, time.UTC)
+
+	next, err := NextRun("@monthly", after)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2030, time.February, 1, 0, 0, 0, 0, time.UTC), next)
+
+	next, err = NextRun("0 9 15 * *", after)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2030, time.February, 15, 9, 0, 0, 0, time.UTC), next)
+
+	next, err = NextRun("@every 168h", after)
+	require.NoError(t, err)
+	require.Equal(t, after.Add(168*time.Hour), next)
+
+	_, err = NextRun("every month", after)
+	require.Error(t, err)
+}
@@ -5,12 +5,24 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+	"time"
+
+	"github.com/dibrito/simple-bank/currency"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/google/uuid"
 )
 
+// minScheduleInterval is the shortest time allowed between two standing order runs.
+const minScheduleInterval = time.Hour
+
+// maxTransferTags is how many tags a transfer may carry.
+const maxTransferTags = 10
+
 var (
 	isValidUserName = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
 	// \s is to space char
 	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidTag      = regexp.MustCompile(`^[a-z0-9_-]+$`).MatchString
 )
 
 func ValidateEmailId(id int64) error {
@@ -70,3 +82,152 @@ func ValidateFullName(name string) error {
 	}
 	return nil
 }
+
+// ValidateIdempotencyKey accepts an empty key, meaning the request is not idempotent.
+// Keys in the namespace of the bank's own transfers are rejected.
+func ValidateIdempotencyKey(key string) error {
+	if key == "" {
+		return nil
+	}
+	if util.IsInternalIdempotencyKey(key) {
+		return errors.New("must not start with a reserved prefix")
+	}
+	return ValidateString(key, 1, 255)
+}
+
+func ValidateRefreshToken(token string) error {
+	if token == "" {
+		return errors.New("must not be empty")
+	}
+	return nil
+}
+
+func ValidateSessionID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return errors.New("must be a valid UUID")
+	}
+	return nil
+}
+
+func ValidateCurrency(code string) error {
+	if !currency.IsSupported(code) {
+		return fmt.Errorf("unsupported currency")
+	}
+	return nil
+}
+
+func ValidateAccountId(id int64) error {
+	if id <= 0 {
+		return errors.New("must be a positive integer")
+	}
+	return nil
+}
+
+// ValidateAccountType accepts an empty type, which opens a checking account.
+func ValidateAccountType(accountType string) error {
+	switch accountType {
+	case "", "checking", "savings":
+		return nil
+	}
+	return fmt.Errorf("must be checking or savings")
+}
+
+// ValidateAccountStatus accepts the statuses an owner can set. Closing an
+// account has its own flow, since its balance must be dealt with first.
+func ValidateAccountStatus(status string) error {
+	switch status {
+	case "active", "frozen":
+		return nil
+	}
+	return fmt.Errorf("must be active or frozen")
+}
+
+func ValidateAmount(amount int64) error {
+	if amount <= 0 {
+		return errors.New("must be greater than zero")
+	}
+	return nil
+}
+
+func ValidatePageID(id int32) error {
+	if id < 1 {
+		return errors.New("must be at least 1")
+	}
+	return nil
+}
+
+func ValidatePageSize(size int32) error {
+	if size < 5 || size > 10 {
+		return fmt.Errorf("must be from %d to %d", 5, 10)
+	}
+	return nil
+}
+
+// ValidateSchedule accepts a cron spec or descriptor that runs at most hourly.
+func ValidateSchedule(schedule string) error {
+	if err := ValidateString(schedule, 1, 100); err != nil {
+		return err
+	}
+
+	next, err := util.NextRun(schedule, time.Now())
+	if err != nil {
+		return fmt.Errorf("must be a cron spec such as \"0 9 1 * *\" or a descriptor such as \"@monthly\"")
+	}
+	if next.IsZero() {
+		return fmt.Errorf("never runs")
+	}
+	after, _ := util.NextRun(schedule, next)
+	if after.Sub(next) < minScheduleInterval {
+		return fmt.Errorf("must not run more than once every %s", minScheduleInterval)
+	}
+	return nil
+}
+
+func ValidateNickname(nickname string) error {
+	return ValidateString(nickname, 1, 50)
+}
+
+// ValidateAccountNickname accepts an empty nickname, accounts don't need one.
+func ValidateAccountNickname(nickname string) error {
+	return ValidateString(nickname, 0, 50)
+}
+
+// ValidateDescription accepts an empty description.
+func ValidateDescription(description string) error {
+	return ValidateString(description, 0, 255)
+}
+
+// ValidateExternalReference accepts an empty reference.
+func ValidateExternalReference(reference string) error {
+	return ValidateString(reference, 0, 100)
+}
+
+func ValidateTag(tag string) error {
+	if err := ValidateString(tag, 1, 30); err != nil {
+		return err
+	}
+
+	if !isValidTag(tag) {
+		return fmt.Errorf("must contain only lowercase letters, digits, dash or underscore")
+	}
+	return nil
+}
+
+// ValidateTags accepts up to maxTransferTags distinct tags.
+func ValidateTags(tags []string) error {
+	if len(tags) > maxTransferTags {
+		return fmt.Errorf("must hold at most %d tags", maxTransferTags)
+	}
+
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if err := ValidateTag(tag); err != nil {
+			return fmt.Errorf("tag %q %w", tag, err)
+		}
+		if seen[tag] {
+			return fmt.Errorf("tag %q is repeated", tag)
+		}
+		seen[tag] = true
+	}
+	return nil
+}
@@ -12,6 +12,11 @@ type TaskDistributor interface {
 		payload *PayloadSendVerifyEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskProcessBatch(
+		ctx context.Context,
+		payload *PayloadProcessBatch,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisDistributor struct {
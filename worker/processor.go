@@ -18,6 +18,11 @@ type TaskProcessor interface {
 	// we need to register the task within the server
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskVoidExpiredHolds(ctx context.Context, task *asynq.Task) error
+	ProcessTaskReconcileLedger(ctx context.Context, task *asynq.Task) error
+	ProcessTaskRunStandingOrders(ctx context.Context, task *asynq.Task) error
+	ProcessTaskProcessBatch(ctx context.Context, task *asynq.Task) error
+	ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
@@ -53,5 +58,10 @@ func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 	//  this handle func analogies are crazyyieee!!
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskVoidExpiredHolds, processor.ProcessTaskVoidExpiredHolds)
+	mux.HandleFunc(TaskReconcileLedger, processor.ProcessTaskReconcileLedger)
+	mux.HandleFunc(TaskRunStandingOrders, processor.ProcessTaskRunStandingOrders)
+	mux.HandleFunc(TaskProcessBatch, processor.ProcessTaskProcessBatch)
+	mux.HandleFunc(TaskAccrueInterest, processor.ProcessTaskAccrueInterest)
 	return processor.server.Start(mux)
 }
@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// periodicTask is the cron spec of a periodic task and how long it stays
+// unique once enqueued. The uniqueness is shorter than the period, so each
+// run is enqueued once however many schedulers are running.
+type periodicTask struct {
+	spec   string
+	unique time.Duration
+}
+
+// periodicTasks maps each periodic task to its schedule.
+var periodicTasks = map[string]periodicTask{
+	TaskVoidExpiredHolds:  {"@every 1m", 50 * time.Second},
+	TaskReconcileLedger:   {"@hourly", 55 * time.Minute},
+	TaskRunStandingOrders: {"@every 1m", 50 * time.Second},
+	// a few minutes past midnight UTC, once the day's transfers have committed
+	TaskAccrueInterest: {"5 0 * * *", 23 * time.Hour},
+}
+
+// NewScheduler enqueues the periodic tasks on their schedule.
+func NewScheduler(redisOpt asynq.RedisClientOpt) (*asynq.Scheduler, error) {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+	})
+
+	for taskType, task := range periodicTasks {
+		_, err := scheduler.Register(task.spec, asynq.NewTask(taskType, nil),
+			asynq.Queue(QueueDefault), asynq.Unique(task.unique))
+		if err != nil {
+			return nil, fmt.Errorf("register task %s:%w", taskType, err)
+		}
+	}
+	return scheduler, nil
+}
@@ -0,0 +1,181 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/dibrito/simple-bank/util"
+	"math/big"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fx"
+	"github.com/dibrito/simple-bank/interest"
+	"github.com/hibiken/asynq"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskAccrueInterest = "task:accrue_interest"
+
+// accrueInterestBatch caps how many accounts are read from the database at once.
+const accrueInterestBatch = 500
+
+// ProcessTaskAccrueInterest accrues interest for yesterday, in UTC, then pays
+// out whatever accrued before the current month. Days missed while the worker
+// was down are not caught up.
+func (processor *RedisTaskProcessor) ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	accrued, err := processor.accrueInterest(ctx, today.AddDate(0, 0, -1), today)
+	if err != nil {
+		return fmt.Errorf("accrue interest:%w", err)
+	}
+
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	posted, failed, err := processor.postInterest(ctx, monthStart)
+	if err != nil {
+		return fmt.Errorf("post interest:%w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Int("accrued", accrued).
+		Int("posted", posted).Int("failed", failed).Msg("processed task")
+	return nil
+}
+
+// accrueInterest records the interest each account earned on day, from its
+// balance at dayEnd. Accounts already accrued for day are skipped, so running
+// it twice is harmless.
+func (processor *RedisTaskProcessor) accrueInterest(ctx context.Context, day, dayEnd time.Time) (int, error) {
+	rates := map[string]*big.Rat{}
+	accrued := 0
+	for {
+		accounts, err := processor.store.ListAccountsToAccrue(ctx, db.ListAccountsToAccrueParams{
+			DayEnd:      dayEnd,
+			AccrualDate: day,
+			PageSize:    accrueInterestBatch,
+		})
+		if err != nil {
+			return accrued, fmt.Errorf("list accounts to accrue:%w", err)
+		}
+
+		for _, account := range accounts {
+			key := account.Type + "/" + account.Currency
+			rate, ok := rates[key]
+			if !ok {
+				rate, err = processor.interestRate(ctx, account.Type, account.Currency, day)
+				if err != nil {
+					return accrued, err
+				}
+				rates[key] = rate
+			}
+
+			// every account gets a row, even at zero, so it is not listed again
+			_, err = processor.store.CreateInterestAccrual(ctx, db.CreateInterestAccrualParams{
+				AccountID:   account.ID,
+				AccrualDate: day,
+				Balance:     account.Balance,
+				AnnualRate:  fx.FormatRate(rate),
+				Amount:      interest.DailyAccrual(account.Balance, rate),
+			})
+			if err != nil {
+				// another run accrued it first
+				if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+					continue
+				}
+				return accrued, fmt.Errorf("create accrual for account %d:%w", account.ID, err)
+			}
+			accrued++
+		}
+
+		if len(accounts) < accrueInterestBatch {
+			return accrued, nil
+		}
+	}
+}
+
+func (processor *RedisTaskProcessor) interestRate(ctx context.Context, accountType, currency string, day time.Time) (*big.Rat, error) {
+	rate, err := processor.store.GetInterestRate(ctx, db.GetInterestRateParams{
+		AccountType:   accountType,
+		Currency:      currency,
+		EffectiveFrom: day,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get %s %s interest rate:%w", accountType, currency, err)
+	}
+	return interest.ParseRate(rate.AnnualRate)
+}
+
+// postInterest pays each open account the interest it accrued before
+// `before`, from the interest expense system account in its currency.
+// A failed payment is logged and retried on the next run.
+func (processor *RedisTaskProcessor) postInterest(ctx context.Context, before time.Time) (posted int, failed int, err error) {
+	var after int64
+	for {
+		rows, err := processor.store.ListUnpostedInterest(ctx, db.ListUnpostedInterestParams{
+			Before:         before,
+			AfterAccountID: after,
+			PageSize:       accrueInterestBatch,
+		})
+		if err != nil {
+			return posted, failed, fmt.Errorf("list unposted interest:%w", err)
+		}
+
+		for _, row := range rows {
+			after = row.AccountID
+			transferID, err := processor.payInterest(ctx, row)
+			if err != nil {
+				failed++
+				log.Error().Err(err).Int64("account_id", row.AccountID).Msg("pay interest")
+				continue
+			}
+
+			err = processor.store.MarkInterestAccrualsPosted(ctx, db.MarkInterestAccrualsPostedParams{
+				TransferID: transferID,
+				AccountID:  row.AccountID,
+				Before:     before,
+			})
+			if err != nil {
+				// the task is retried and the payment replayed, not repeated
+				return posted, failed, fmt.Errorf("mark interest of account %d posted:%w", row.AccountID, err)
+			}
+			posted++
+		}
+
+		if len(rows) < accrueInterestBatch {
+			return posted, failed, nil
+		}
+	}
+}
+
+// payInterest credits the accrued interest of an account. Amounts that
+// rounded to nothing are settled without a transfer.
+// The transfer is keyed by the first day it pays for, so a retry replays it
+// instead of paying twice.
+func (processor *RedisTaskProcessor) payInterest(ctx context.Context, row db.ListUnpostedInterestRow) (sql.NullInt64, error) {
+	if row.Amount <= 0 {
+		return sql.NullInt64{}, nil
+	}
+
+	expenseID, err := processor.store.GetSystemAccountID(ctx, db.GetSystemAccountIDParams{
+		Purpose:  db.SystemAccountInterestExpense,
+		Currency: row.Currency,
+	})
+	if err != nil {
+		return sql.NullInt64{}, fmt.Errorf("get %s interest expense account:%w", row.Currency, err)
+	}
+
+	result, err := processor.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID:  expenseID,
+		ToAccountID:    row.AccountID,
+		Amount:         row.Amount,
+		Currency:       row.Currency,
+		Description:    "interest",
+		Tags:           []string{"interest"},
+		Username:       row.Owner.String,
+		IdempotencyKey: util.InternalIdempotencyKey("interest:%d:%s", row.AccountID, row.FirstAccrualDate.Format("2006-01-02")),
+	})
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	return sql.NullInt64{Int64: result.Transfer.ID, Valid: true}, nil
+}
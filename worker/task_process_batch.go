@@ -0,0 +1,144 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/dibrito/simple-bank/util"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fee"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+type PayloadProcessBatch struct {
+	BatchID int64 `json:"batch_id"`
+}
+
+const TaskProcessBatch = "task:process_batch"
+
+// processBatchChunk is how many pending items are loaded at a time.
+const processBatchChunk = 50
+
+func (distributor *RedisDistributor) DistributeTaskProcessBatch(
+	ctx context.Context,
+	payload *PayloadProcessBatch,
+	opts ...asynq.Option,
+) error {
+
+	json, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload:%w", err)
+	}
+
+	task := asynq.NewTask(TaskProcessBatch, json, opts...)
+	taskInfo, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task :%w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", taskInfo.Queue).Int("max_retry", taskInfo.MaxRetry).
+		Msg("enqued task")
+	return nil
+}
+
+// ProcessTaskProcessBatch executes the pending items of a batch in chunks,
+// each item as its own transfer.
+// A failed item doesn't stop the batch, its reason is kept on the item.
+func (processor *RedisTaskProcessor) ProcessTaskProcessBatch(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadProcessBatch
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("unmarshal payload:%w", asynq.SkipRetry)
+	}
+
+	batch, err := processor.store.GetBatch(ctx, payload.BatchID)
+	if err != nil {
+		return fmt.Errorf("get batch:%w", err)
+	}
+	if batch.Status == db.BatchStatusCompleted {
+		return nil
+	}
+
+	for {
+		items, err := processor.store.ListPendingBatchItems(ctx, db.ListPendingBatchItemsParams{
+			BatchID: batch.ID,
+			Limit:   processBatchChunk,
+		})
+		if err != nil {
+			return fmt.Errorf("list pending batch items:%w", err)
+		}
+		if len(items) == 0 {
+			break
+		}
+
+		for _, item := range items {
+			batch, err = processor.runBatchItem(ctx, batch, item)
+			if err != nil {
+				return fmt.Errorf("run batch item %d:%w", item.ID, err)
+			}
+		}
+	}
+
+	batch, err = processor.store.UpdateBatchProgress(ctx, db.UpdateBatchProgressParams{
+		ID:          batch.ID,
+		Status:      db.BatchStatusCompleted,
+		CompletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("complete batch:%w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Int64("batch_id", batch.ID).
+		Int32("succeeded", batch.SucceededCount).Int32("failed", batch.FailedCount).
+		Msg("processed task")
+	return nil
+}
+
+// runBatchItem executes one item and records its outcome together with the
+// progress of the batch, which it returns.
+// Only business errors fail the item, any other error is returned so the
+// task is retried.
+// The transfer is keyed by the item, so retrying the task never pays twice.
+func (processor *RedisTaskProcessor) runBatchItem(ctx context.Context, batch db.Batch, item db.BatchItem) (db.Batch, error) {
+	update := db.UpdateBatchItemStatusParams{
+		ID:      item.ID,
+		BatchID: batch.ID,
+		Status:  db.BatchItemStatusSucceeded,
+	}
+
+	transferFee, err := fee.ForTransfer(ctx, processor.store, batch.Currency, batch.Currency, item.Amount)
+	if err != nil {
+		return batch, fmt.Errorf("compute fee:%w", err)
+	}
+
+	result, err := processor.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID:  batch.FromAccountID,
+		ToAccountID:    item.ToAccountID,
+		Amount:         item.Amount,
+		Currency:       batch.Currency,
+		Fee:            transferFee,
+		Username:       batch.Owner,
+		IdempotencyKey: util.InternalIdempotencyKey("batch:%d:%d", batch.ID, item.RowNumber),
+	})
+	if err != nil {
+		reason, ok := transferFailureReason(err)
+		if !ok {
+			return batch, fmt.Errorf("transfer:%w", err)
+		}
+		update.Status = db.BatchItemStatusFailed
+		update.FailureReason = reason
+	} else {
+		update.TransferID = sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+	}
+
+	recorded, err := processor.store.RecordBatchItemTx(ctx, update)
+	if err != nil {
+		return batch, fmt.Errorf("record batch item:%w", err)
+	}
+	return recorded.Batch, nil
+}
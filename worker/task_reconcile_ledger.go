@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskReconcileLedger = "task:reconcile_ledger"
+
+func (processor *RedisTaskProcessor) ProcessTaskReconcileLedger(ctx context.Context, task *asynq.Task) error {
+	report, err := processor.store.ReconcileTx(ctx)
+	if err != nil {
+		return fmt.Errorf("reconcile ledger:%w", err)
+	}
+
+	event := log.Info()
+	if report.DiscrepancyCount > 0 {
+		event = log.Error()
+	}
+	event.Str("type", task.Type()).Int64("report_id", report.ID).
+		Int64("accounts_checked", report.AccountsChecked).
+		Int64("transfers_checked", report.TransfersChecked).
+		Int32("discrepancies", report.DiscrepancyCount).
+		Msg("processed task")
+	return nil
+}
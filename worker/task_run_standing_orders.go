@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/dibrito/simple-bank/fee"
+	"github.com/dibrito/simple-bank/money"
+	"github.com/dibrito/simple-bank/util"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskRunStandingOrders = "task:run_standing_orders"
+
+// runStandingOrdersBatch caps how many orders a single run executes,
+// the next run picks up the rest.
+const runStandingOrdersBatch = 100
+
+func (processor *RedisTaskProcessor) ProcessTaskRunStandingOrders(ctx context.Context, task *asynq.Task) error {
+	orders, err := processor.store.ListDueStandingOrders(ctx, runStandingOrdersBatch)
+	if err != nil {
+		return fmt.Errorf("list due standing orders:%w", err)
+	}
+
+	executed, failed := 0, 0
+	for _, order := range orders {
+		execution, ran, err := processor.runStandingOrder(ctx, order)
+		if err != nil {
+			return fmt.Errorf("run standing order %d:%w", order.ID, err)
+		}
+		if !ran {
+			continue
+		}
+		executed++
+		if execution.Status == db.ExecutionStatusFailed {
+			failed++
+			processor.notifyStandingOrderFailure(ctx, order, execution)
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("executed", executed).
+		Int("failed", failed).Msg("processed task")
+	return nil
+}
+
+// transferFailureReasons are the transfer errors caused by the accounts or
+// their limits, with the reason shown to the account owner. Retrying does
+// not fix them.
+var transferFailureReasons = []struct {
+	err    error
+	reason string
+}{
+	{db.ErrInsufficientFunds, "the account does not have enough funds"},
+	{db.ErrAccountFrozen, "the account is frozen"},
+	{db.ErrAccountClosed, "one of the accounts is closed"},
+	{db.ErrSingleTransferLimit, "the amount is above your single transfer limit"},
+	{db.ErrDailyTransferLimit, "your daily transfer limit is reached"},
+}
+
+// transferFailureReason returns the reason a transfer failed with err, or
+// false if err is not a business error and the transfer should be retried.
+func transferFailureReason(err error) (string, bool) {
+	for _, failure := range transferFailureReasons {
+		if errors.Is(err, failure.err) {
+			return failure.reason, true
+		}
+	}
+	return "", false
+}
+
+// runStandingOrder executes the transfer due at order.NextRunAt, records the
+// outcome and schedules the next run.
+// Only business errors are recorded as a failed run, any other error is
+// returned so the task is retried.
+// The transfer is keyed by the run, so retrying the task never pays twice.
+// It returns false if another task already recorded the run.
+func (processor *RedisTaskProcessor) runStandingOrder(ctx context.Context, order db.StandingOrder) (db.StandingOrderExecution, bool, error) {
+	execution := db.CreateStandingOrderExecutionParams{
+		StandingOrderID: order.ID,
+		Status:          db.ExecutionStatusSucceeded,
+		RunAt:           order.NextRunAt,
+	}
+
+	transferFee, err := fee.ForTransfer(ctx, processor.store, order.Currency, order.Currency, order.Amount)
+	if err != nil {
+		return db.StandingOrderExecution{}, false, fmt.Errorf("compute fee:%w", err)
+	}
+
+	result, err := processor.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID:  order.FromAccountID,
+		ToAccountID:    order.ToAccountID,
+		Amount:         order.Amount,
+		Currency:       order.Currency,
+		Fee:            transferFee,
+		Username:       order.Owner,
+		IdempotencyKey: util.InternalIdempotencyKey("standing_order:%d:%d", order.ID, order.NextRunAt.Unix()),
+	})
+	if err != nil {
+		reason, ok := transferFailureReason(err)
+		if !ok {
+			// nothing is recorded, so the retry runs the same transfer again
+			return db.StandingOrderExecution{}, false, fmt.Errorf("transfer:%w", err)
+		}
+		execution.Status = db.ExecutionStatusFailed
+		execution.FailureReason = reason
+	} else {
+		execution.TransferID = sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+	}
+
+	// another task, or this one before a retry, may have recorded the run
+	// already; the next run is still scheduled in case that task did not
+	recorded, err := processor.store.CreateStandingOrderExecution(ctx, execution)
+	ran := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		return recorded, false, fmt.Errorf("create execution:%w", err)
+	}
+
+	// runs missed while the worker was down are skipped, not caught up
+	nextRunAt, err := util.NextRun(order.Schedule, time.Now())
+	if err != nil {
+		return recorded, false, err
+	}
+	update := db.UpdateStandingOrderParams{
+		ID:        order.ID,
+		NextRunAt: sql.NullTime{Time: nextRunAt, Valid: true},
+	}
+	if order.EndAt.Valid && nextRunAt.After(order.EndAt.Time) {
+		update.Status = sql.NullString{String: db.StandingOrderStatusCompleted, Valid: true}
+	}
+
+	_, err = processor.store.UpdateStandingOrder(ctx, update)
+	if err != nil {
+		return recorded, false, fmt.Errorf("schedule next run:%w", err)
+	}
+	return recorded, ran, nil
+}
+
+// notifyStandingOrderFailure emails the owner of a failed order.
+// The run is already recorded, so a mail failure is only logged.
+func (processor *RedisTaskProcessor) notifyStandingOrderFailure(ctx context.Context, order db.StandingOrder, execution db.StandingOrderExecution) {
+	user, err := processor.store.GetUser(ctx, order.Owner)
+	if err != nil {
+		log.Error().Err(err).Int64("standing_order_id", order.ID).Msg("get standing order owner")
+		return
+	}
+
+	subject := "Standing order failed"
+	content := fmt.Sprintf(`Hello %s,</br>
+	Your standing order #%d of %s %s from account #%d to account #%d
+	scheduled for %s could not be executed: %s.</br>`,
+		user.FullName, order.ID, money.Money{Amount: order.Amount, Currency: order.Currency}, order.Currency, order.FromAccountID, order.ToAccountID,
+		execution.RunAt.Format(time.RFC1123), execution.FailureReason)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		log.Error().Err(err).Int64("standing_order_id", order.ID).Msg("send standing order failure email")
+	}
+}
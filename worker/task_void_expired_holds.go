@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/dibrito/simple-bank/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskVoidExpiredHolds = "task:void_expired_holds"
+
+// voidExpiredHoldsBatch caps how many holds a single run voids,
+// the next run picks up the rest.
+const voidExpiredHoldsBatch = 100
+
+func (processor *RedisTaskProcessor) ProcessTaskVoidExpiredHolds(ctx context.Context, task *asynq.Task) error {
+	holds, err := processor.store.ListExpiredHolds(ctx, voidExpiredHoldsBatch)
+	if err != nil {
+		return fmt.Errorf("list expired holds:%w", err)
+	}
+
+	voided := 0
+	for _, hold := range holds {
+		_, err := processor.store.VoidHoldTx(ctx, db.VoidHoldTxParams{HoldID: hold.ID})
+		if err != nil {
+			// captured or voided since it was listed
+			if errors.Is(err, db.ErrHoldNotPending) {
+				continue
+			}
+			return fmt.Errorf("void hold %d:%w", hold.ID, err)
+		}
+		voided++
+	}
+
+	log.Info().Str("type", task.Type()).Int("voided", voided).
+		Msg("processed task")
+	return nil
+}